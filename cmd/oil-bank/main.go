@@ -2,50 +2,160 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"time"
 
+	"github.com/crazydw4rf/oil-bank-backend/internal/auth"
 	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/controller"
 	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/middleware"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/metrics"
 	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 	"github.com/crazydw4rf/oil-bank-backend/internal/usecase"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
 )
 
-func start(lc fx.Lifecycle, app *fiber.App, cfg *config.Config) {
+func start(lc fx.Lifecycle, shutdowner fx.Shutdowner, app *fiber.App, db services.DatabaseService, drain *services.DrainState, cfg *config.Config, log *slog.Logger) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			fmt.Println("Starting app...")
 			addr := fmt.Sprintf("%s:%d", cfg.APP_HOST, cfg.APP_PORT)
-			go app.Listen(addr)
+
+			// listen dilakukan di sini, bukan di goroutine, supaya startup gagal jika port tidak bisa dipakai
+			ln, err := net.Listen(app.Config().Network, addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", addr, err)
+			}
+
+			log.Info("starting app", "addr", addr, "env", config.APP_ENV)
+			go func() {
+				if err := app.Listener(ln); err != nil {
+					log.Error("http server stopped unexpectedly", "error", err)
+					_ = shutdowner.Shutdown(fx.ExitCode(1))
+				}
+			}()
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			fmt.Println("Stopping app...")
-			err := app.ShutdownWithContext(ctx)
+			log.Info("stopping app, draining in-flight requests", "timeout", cfg.SHUTDOWN_TIMEOUT, "drain_delay", cfg.SHUTDOWN_DRAIN_DELAY)
+			drain.StartDraining()
+
+			shutdownCtx, cancel := context.WithTimeout(ctx, cfg.SHUTDOWN_TIMEOUT)
+			defer cancel()
+
+			// selama drain delay hanya probe yang dilayani, request lain langsung ditolak 503 oleh
+			// RejectWhenDraining, sampai load balancer melihat /readyz gagal dan berhenti mengirim
+			// request ke instance ini. Delay diambil dari SHUTDOWN_TIMEOUT, sisanya untuk request yang sedang berjalan.
+			select {
+			case <-time.After(cfg.SHUTDOWN_DRAIN_DELAY):
+			case <-shutdownCtx.Done():
+			}
+
+			err := app.ShutdownWithContext(shutdownCtx)
+			if err != nil {
+				log.Warn("http server did not finish in-flight requests before deadline", "error", err)
+			}
+
+			// pool database ditutup setelah server berhenti supaya request yang sedang berjalan
+			// masih bisa menyelesaikan transaction-nya
+			closed := make(chan error, 1)
+			go func() { closed <- db.Close() }()
+
+			select {
+			case dbErr := <-closed:
+				err = errors.Join(err, dbErr)
+			case <-ctx.Done():
+				err = errors.Join(err, fmt.Errorf("failed to close database: %w", ctx.Err()))
+			}
+
+			log.Info("app stopped")
 			return err
 		},
 	})
 }
 
+// registerCleanup mendaftarkan penghapusan record kadaluarsa yang dijalankan oleh CleanupJob
+func registerCleanup(job *services.CleanupJob, revokedTokenRepo repository.IRevokedTokenRepository, refreshTokenRepo repository.IRefreshTokenRepository, idempotencyKeyRepo repository.IIdempotencyKeyRepository, loginAttemptRepo repository.ILoginAttemptRepository, cfg *config.Config) {
+	job.Register("revoked_token", revokedTokenRepo.DeleteExpired)
+	job.Register("refresh_token", refreshTokenRepo.DeleteExpired)
+	job.Register("idempotency_key", idempotencyKeyRepo.DeleteExpired)
+	// store memory menghapus record expired sendiri setiap ada login gagal baru
+	if cfg.LOGIN_ATTEMPT_STORE == "postgres" {
+		job.Register("login_attempt", loginAttemptRepo.DeleteExpired)
+	}
+}
+
 func publicRoutes(app *fiber.App) {
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"app_name": "oil-bank-backend", "version": config.APP_VERSION})
 	})
 }
 
+// dumpConfig menampilkan config yang terbaca dengan secret disamarkan, lalu hasil validasinya.
+// Dijalankan lewat: oil-bank config
+func dumpConfig() int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+
+	if err := cfg.Dump(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to dump config: %v\n", err)
+		return 1
+	}
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(dumpConfig())
+	}
+
+	// config dibaca sebelum fx supaya SHUTDOWN_TIMEOUT bisa dipakai sebagai batas waktu OnStop
+	cfg, err := config.InitConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	app := fx.New(
-		fx.Provide(config.InitConfig, services.NewFiberService, services.NewDatabaseService),
+		fx.WithLogger(func(log *slog.Logger) fxevent.Logger {
+			return &fxevent.SlogLogger{Logger: log}
+		}),
+		fx.StopTimeout(cfg.SHUTDOWN_TIMEOUT+config.DB_CLOSE_TIMEOUT),
+		fx.Supply(cfg),
+		fx.Provide(services.NewLogger, services.NewDrainState, services.NewFiberService, services.NewDatabaseService, services.NewUnitOfWork, services.NewCleanupJob),
+		fx.Provide(metrics.New),
+		fx.Provide(services.NewHealthService, controller.NewHealthController),
+		fx.Provide(repository.NewRevokedTokenRepository, auth.NewRevocationStore, auth.NewAccessTokenKeys, controller.NewJWKSController),
+		fx.Provide(repository.NewLoginAttemptRepository, auth.NewLoginAttemptStore, auth.NewLoginThrottle),
 		fx.Provide(middleware.NewHTTPMiddleware),
-		fx.Provide(repository.NewUserRepository, usecase.NewUserUsecase, controller.NewUserController),
-		fx.Provide(repository.NewTransactionRepository, usecase.NewTransactionUsecase, controller.NewTransactionController),
+		fx.Provide(repository.NewUserRepository, repository.NewRefreshTokenRepository, usecase.NewUserUsecase, controller.NewUserController),
+		fx.Provide(repository.NewTransactionRepository, repository.NewIdempotencyKeyRepository, repository.NewSyncedTransactionRepository, usecase.NewTransactionUsecase, controller.NewTransactionController),
 		fx.Provide(repository.NewReportRepository, usecase.NewReportUsecase, controller.NewReportController),
 		fx.Provide(repository.NewOilRepository, usecase.NewOilUsecase, controller.NewOilController),
-		fx.Invoke(publicRoutes, controller.SetupUserRouter, controller.SetupOilRouter, controller.SetupTransactionRouter, controller.SetupReportRouter),
+		fx.Provide(repository.NewSellerRepository, usecase.NewSellerUsecase, controller.NewSellerController),
+		fx.Provide(repository.NewCompanyRepository, usecase.NewCompanyUsecase, controller.NewCompanyController),
+		fx.Invoke(services.SetupMetrics),
+		fx.Invoke(publicRoutes, controller.SetupHealthRouter, controller.SetupJWKSRouter, controller.SetupUserRouter, controller.SetupOilRouter, controller.SetupTransactionRouter, controller.SetupReportRouter),
+		fx.Invoke(controller.SetupSellerRouter),
+		fx.Invoke(controller.SetupCompanyRouter),
 		fx.Invoke(start),
+		// dipasang setelah start supaya OnStop-nya berjalan lebih dulu, sebelum pool database ditutup
+		fx.Invoke(registerCleanup, services.StartCleanupJob),
 	)
 
 	app.Run()
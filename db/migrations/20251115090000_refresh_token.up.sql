@@ -0,0 +1,17 @@
+CREATE TABLE "RefreshToken" (
+  id BIGSERIAL,
+  user_id BIGINT NOT NULL,
+  token_id UUID NOT NULL UNIQUE,
+  family_id UUID NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (id),
+  FOREIGN KEY (user_id) REFERENCES "User"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_token_user_id ON "RefreshToken"(user_id);
+CREATE INDEX idx_refresh_token_family_id ON "RefreshToken"(family_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_revoked_token_expires_at;
+
+DROP TABLE IF EXISTS "UserTokenRevocation";
+DROP TABLE IF EXISTS "RevokedToken";
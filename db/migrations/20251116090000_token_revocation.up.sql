@@ -0,0 +1,21 @@
+CREATE TABLE "RevokedToken" (
+  token_id UUID NOT NULL,
+  user_id BIGINT NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+
+  revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (token_id),
+  FOREIGN KEY (user_id) REFERENCES "User"(id) ON DELETE CASCADE
+);
+
+-- Semua token milik user yang diterbitkan sebelum revoked_before dianggap dicabut
+CREATE TABLE "UserTokenRevocation" (
+  user_id BIGINT NOT NULL,
+  revoked_before TIMESTAMPTZ NOT NULL,
+
+  PRIMARY KEY (user_id),
+  FOREIGN KEY (user_id) REFERENCES "User"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_revoked_token_expires_at ON "RevokedToken"(expires_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_distribute_transaction_company_created_at;
+
+ALTER TABLE "DistributeTransaction" DROP COLUMN IF EXISTS received_at;
@@ -0,0 +1,3 @@
+ALTER TABLE "DistributeTransaction" ADD COLUMN received_at TIMESTAMPTZ;
+
+CREATE INDEX idx_distribute_transaction_company_created_at ON "DistributeTransaction"(company_id, created_at DESC);
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS trg_reverse_oil_on_distribute_cancel ON "DistributeTransaction";
+DROP FUNCTION IF EXISTS reverse_oil_on_distribute_cancel();
+
+DROP TRIGGER IF EXISTS trg_reverse_oil_on_sell_cancel ON "SellTransaction";
+DROP FUNCTION IF EXISTS reverse_oil_on_sell_cancel();
+
+ALTER TABLE "DistributeTransaction" DROP COLUMN IF EXISTS cancel_reason;
+ALTER TABLE "DistributeTransaction" DROP COLUMN IF EXISTS cancelled_at;
+
+ALTER TABLE "SellTransaction" DROP COLUMN IF EXISTS cancel_reason;
+ALTER TABLE "SellTransaction" DROP COLUMN IF EXISTS cancelled_at;
@@ -0,0 +1,51 @@
+ALTER TABLE "SellTransaction" ADD COLUMN cancelled_at TIMESTAMPTZ;
+ALTER TABLE "SellTransaction" ADD COLUMN cancel_reason TEXT;
+
+ALTER TABLE "DistributeTransaction" ADD COLUMN cancelled_at TIMESTAMPTZ;
+ALTER TABLE "DistributeTransaction" ADD COLUMN cancel_reason TEXT;
+
+-- Function to reverse Oil inventory when SellTransaction is cancelled
+CREATE OR REPLACE FUNCTION reverse_oil_on_sell_cancel()
+RETURNS TRIGGER AS $$
+BEGIN
+  -- Remove the purchased volume from collector's inventory
+  UPDATE "Oil"
+  SET total_volume = total_volume - OLD.volume,
+      updated_at = NOW()
+  WHERE collector_id = OLD.collector_id;
+
+  -- The oil may already have been distributed to a company
+  IF (SELECT total_volume FROM "Oil" WHERE collector_id = OLD.collector_id) < 0 THEN
+    RAISE EXCEPTION 'Insufficient oil inventory for collector_id %', OLD.collector_id
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'oil_total_volume_non_negative';
+  END IF;
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_reverse_oil_on_sell_cancel
+AFTER UPDATE OF cancelled_at ON "SellTransaction"
+FOR EACH ROW
+WHEN (OLD.cancelled_at IS NULL AND NEW.cancelled_at IS NOT NULL)
+EXECUTE FUNCTION reverse_oil_on_sell_cancel();
+
+-- Function to reverse Oil inventory when DistributeTransaction is cancelled
+CREATE OR REPLACE FUNCTION reverse_oil_on_distribute_cancel()
+RETURNS TRIGGER AS $$
+BEGIN
+  -- Return the distributed volume to collector's inventory
+  UPDATE "Oil"
+  SET total_volume = total_volume + OLD.volume,
+      updated_at = NOW()
+  WHERE collector_id = OLD.collector_id;
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_reverse_oil_on_distribute_cancel
+AFTER UPDATE OF cancelled_at ON "DistributeTransaction"
+FOR EACH ROW
+WHEN (OLD.cancelled_at IS NULL AND NEW.cancelled_at IS NOT NULL)
+EXECUTE FUNCTION reverse_oil_on_distribute_cancel();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_idempotency_key_expires_at;
+
+DROP TABLE IF EXISTS "IdempotencyKey";
@@ -0,0 +1,16 @@
+-- Respons pertama dari request dengan Idempotency-Key disimpan supaya retry dengan key yang sama
+-- tidak membuat transaksi ganda. response_body NULL selama request pertama masih diproses.
+CREATE TABLE "IdempotencyKey" (
+  collector_id BIGINT NOT NULL,
+  idempotency_key TEXT NOT NULL,
+  request_hash TEXT NOT NULL,
+  response_body JSONB,
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMPTZ NOT NULL,
+
+  PRIMARY KEY (collector_id, idempotency_key),
+  FOREIGN KEY (collector_id) REFERENCES "Collector"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_idempotency_key_expires_at ON "IdempotencyKey"(expires_at);
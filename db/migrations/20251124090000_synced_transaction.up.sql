@@ -0,0 +1,14 @@
+-- Catatan transaksi yang dikirim dari perangkat collector saat offline.
+-- client_id dibuat oleh perangkat, dipakai supaya pengiriman ulang tidak membuat transaksi ganda.
+CREATE TABLE "SyncedTransaction" (
+  collector_id BIGINT NOT NULL,
+  client_id UUID NOT NULL,
+  transaction_type TEXT NOT NULL,
+  transaction_id BIGINT,
+  client_created_at TIMESTAMPTZ NOT NULL,
+
+  synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (collector_id, client_id),
+  FOREIGN KEY (collector_id) REFERENCES "Collector"(id) ON DELETE CASCADE
+);
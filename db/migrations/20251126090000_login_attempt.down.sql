@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_login_attempt_expires_at;
+
+DROP TABLE IF EXISTS "LoginAttempt";
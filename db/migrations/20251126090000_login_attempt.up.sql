@@ -0,0 +1,13 @@
+-- Jumlah login gagal per key ("email:<email>" atau "ip:<alamat>") untuk membatasi brute-force.
+-- Record yang sudah melewati expires_at dianggap tidak ada dan hitungan dimulai dari awal.
+CREATE TABLE "LoginAttempt" (
+  attempt_key TEXT NOT NULL,
+  failures INT NOT NULL DEFAULT 0,
+  locked_until TIMESTAMPTZ,
+
+  expires_at TIMESTAMPTZ NOT NULL,
+
+  PRIMARY KEY (attempt_key)
+);
+
+CREATE INDEX idx_login_attempt_expires_at ON "LoginAttempt"(expires_at);
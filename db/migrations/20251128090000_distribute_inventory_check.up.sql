@@ -0,0 +1,20 @@
+-- Raise a named check_violation so the application can tell an insufficient inventory
+-- apart from other errors, the same way reverse_oil_on_sell_cancel does
+CREATE OR REPLACE FUNCTION update_oil_on_distribute()
+RETURNS TRIGGER AS $$
+BEGIN
+  -- Subtract volume from collector's inventory
+  UPDATE "Oil"
+  SET total_volume = total_volume - NEW.volume,
+      updated_at = NOW()
+  WHERE collector_id = NEW.collector_id;
+
+  -- Check if collector has enough oil
+  IF (SELECT total_volume FROM "Oil" WHERE collector_id = NEW.collector_id) < 0 THEN
+    RAISE EXCEPTION 'Insufficient oil inventory for collector_id %', NEW.collector_id
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'oil_total_volume_non_negative';
+  END IF;
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
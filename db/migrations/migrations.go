@@ -0,0 +1,34 @@
+// Package migrations menyimpan file migrasi SQL ke dalam binary supaya aplikasi
+// tahu versi skema yang diharapkan tanpa membaca folder db/migrations saat runtime.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.up.sql
+var FS embed.FS
+
+// LatestVersion mengembalikan versi migrasi terbaru, yaitu prefix angka terbesar pada nama file.
+// Nilainya sama dengan kolom version di tabel schema_migrations setelah `migrate up`.
+func LatestVersion() uint {
+	entries, _ := fs.ReadDir(FS, ".")
+
+	var latest uint
+	for _, entry := range entries {
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found {
+			continue
+		}
+
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err == nil && uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+
+	return latest
+}
@@ -7,40 +7,84 @@ import (
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type JWTClaims struct {
-	CollectorID string `json:"collector_id"`
+	Role      entity.UserType `json:"role"`
+	ProfileID string          `json:"profile_id"`
+	// SessionID adalah family id refresh token, dipakai logout untuk mencabut refresh token
+	// tanpa perlu membaca cookie
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(user *entity.UserWithCollector, cfg *config.Config) (*entity.UserWithCollector, error) {
-	at, err := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
-		CollectorID: strconv.FormatInt(user.CollectorId, 10),
+// GenerateToken membuat pasangan access token dan refresh token baru.
+// Access token ditandatangani dengan keys, refresh token selalu HS256 dengan JWT_REFRESH_TOKEN_SECRET.
+// Claims dari refresh token dikembalikan supaya jti dan waktu kadaluarsanya bisa disimpan.
+func GenerateToken(user *entity.UserWithProfile, familyId string, keys *AccessTokenKeys, cfg *config.Config) (*entity.UserWithProfile, *JWTClaims, error) {
+	now := time.Now()
+	subject := strconv.FormatInt(user.Id, 10)
+	profileId := strconv.FormatInt(user.ProfileId, 10)
+
+	at, err := keys.Sign(JWTClaims{
+		Role:      user.UserType,
+		ProfileID: profileId,
+		SessionID: familyId,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   strconv.FormatInt(user.Id, 10),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 15)),
+			ID:        uuid.NewString(),
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(cfg.ACCESS_TOKEN_EXPIRATION_TIME)),
 		},
-	}).
-		SignedString([]byte(cfg.JWT_ACCESS_TOKEN_SECRET))
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	rt, err := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
-		CollectorID: strconv.FormatInt(user.CollectorId, 10),
+	rtClaims := &JWTClaims{
+		Role:      user.UserType,
+		ProfileID: profileId,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   strconv.FormatInt(user.Id, 10),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24 * 30)),
+			ID:        uuid.NewString(),
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(cfg.REFRESH_TOKEN_EXPIRATION_TIME)),
 		},
-	}).
+	}
+	rt, err := jwt.NewWithClaims(jwt.SigningMethodHS256, rtClaims).
 		SignedString([]byte(cfg.JWT_REFRESH_TOKEN_SECRET))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	user.AccessToken = at
 	user.RefreshToken = rt
 
-	return user, nil
+	return user, rtClaims, nil
+}
+
+func ParseAccessToken(tokenString string, keys *AccessTokenKeys) (*JWTClaims, error) {
+	return parseToken(tokenString, keys.Method(), keys.keyfunc)
+}
+
+func ParseRefreshToken(tokenString string, cfg *config.Config) (*JWTClaims, error) {
+	return parseToken(tokenString, jwt.SigningMethodHS256, func(t *jwt.Token) (any, error) {
+		return []byte(cfg.JWT_REFRESH_TOKEN_SECRET), nil
+	})
+}
+
+// parseToken mengembalikan error dari package jwt apa adanya supaya
+// pemanggil bisa membedakan penyebabnya dengan errors.Is
+func parseToken(tokenString string, method jwt.SigningMethod, keyfunc jwt.Keyfunc) (*JWTClaims, error) {
+	claims := new(JWTClaims)
+	_, err := jwt.ParseWithClaims(tokenString, claims, keyfunc,
+		jwt.WithValidMethods([]string{method.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
 }
@@ -0,0 +1,263 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const MIN_RSA_KEY_BITS = 2048
+
+var errUnknownKeyId = errors.New("unknown key id")
+
+// AccessTokenKeys menyimpan key untuk menandatangani dan memverifikasi access token.
+//
+// Mode HS256 memakai JWT_ACCESS_TOKEN_SECRET tanpa kid. Mode RS256 dan EdDSA menandatangani
+// dengan private key aktif dan header kid, sedangkan verifikasi menerima semua public key
+// yang terdaftar supaya token yang ditandatangani key lama tetap valid selama rotasi.
+type AccessTokenKeys struct {
+	method     jwt.SigningMethod
+	keyId      string
+	signKey    any
+	verifyKeys map[string]crypto.PublicKey
+	secret     []byte
+}
+
+// JWK adalah public key dalam format RFC 7517, hanya field untuk RSA dan Ed25519
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func NewAccessTokenKeys(cfg *config.Config) (*AccessTokenKeys, error) {
+	switch cfg.JWT_SIGNING_METHOD {
+	case "", jwt.SigningMethodHS256.Alg():
+		return &AccessTokenKeys{method: jwt.SigningMethodHS256, secret: []byte(cfg.JWT_ACCESS_TOKEN_SECRET)}, nil
+	case jwt.SigningMethodRS256.Alg():
+		return loadAsymmetricKeys(jwt.SigningMethodRS256, cfg)
+	case jwt.SigningMethodEdDSA.Alg():
+		return loadAsymmetricKeys(jwt.SigningMethodEdDSA, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported JWT_SIGNING_METHOD %q", cfg.JWT_SIGNING_METHOD)
+	}
+}
+
+func loadAsymmetricKeys(method jwt.SigningMethod, cfg *config.Config) (*AccessTokenKeys, error) {
+	signKey, err := readPrivateKey(cfg.JWT_SIGNING_KEY_PATH)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := signKey.Public()
+	if err := checkKey(method, publicKey); err != nil {
+		return nil, fmt.Errorf("JWT_SIGNING_KEY_PATH: %w", err)
+	}
+
+	keys := &AccessTokenKeys{
+		method:     method,
+		keyId:      cfg.JWT_SIGNING_KEY_ID,
+		signKey:    signKey,
+		verifyKeys: map[string]crypto.PublicKey{cfg.JWT_SIGNING_KEY_ID: publicKey},
+	}
+
+	if cfg.JWT_VERIFICATION_KEYS_DIR != "" {
+		if err := keys.loadVerificationKeys(cfg.JWT_VERIFICATION_KEYS_DIR); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// loadVerificationKeys membaca public key tambahan dari dir, kid diambil dari nama file tanpa .pem
+func (k *AccessTokenKeys) loadVerificationKeys(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return fmt.Errorf("JWT_VERIFICATION_KEYS_DIR: %w", err)
+	}
+
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+		if _, ok := k.verifyKeys[kid]; ok {
+			// public key dari key aktif sudah terdaftar
+			if kid == k.keyId {
+				continue
+			}
+			return fmt.Errorf("duplicate verification key id %q", kid)
+		}
+
+		publicKey, err := readPublicKey(path)
+		if err != nil {
+			return err
+		}
+		if err := checkKey(k.method, publicKey); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		k.verifyKeys[kid] = publicKey
+	}
+
+	return nil
+}
+
+func (k *AccessTokenKeys) Method() jwt.SigningMethod {
+	return k.method
+}
+
+// Sign menandatangani claims dengan key aktif, header kid hanya diisi pada mode asymmetric
+func (k *AccessTokenKeys) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.secret != nil {
+		return token.SignedString(k.secret)
+	}
+
+	token.Header["kid"] = k.keyId
+	return token.SignedString(k.signKey)
+}
+
+// keyfunc memilih key verifikasi berdasarkan header kid
+func (k *AccessTokenKeys) keyfunc(t *jwt.Token) (any, error) {
+	if k.secret != nil {
+		return k.secret, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key, ok := k.verifyKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownKeyId, kid)
+	}
+
+	return key, nil
+}
+
+// JWKS mengembalikan semua public key verifikasi, kosong pada mode HS256
+// karena secret tidak boleh dibagikan
+func (k *AccessTokenKeys) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(k.verifyKeys))}
+
+	for _, kid := range slices.Sorted(maps.Keys(k.verifyKeys)) {
+		jwk := JWK{Kid: kid, Use: "sig", Alg: k.method.Alg()}
+
+		switch key := k.verifyKeys[kid].(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(key)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported private key type %T", path, key)
+	}
+
+	return signer, nil
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+
+	return block, nil
+}
+
+// checkKey memastikan jenis key sesuai dengan method dan panjang key RSA cukup
+func checkKey(method jwt.SigningMethod, key crypto.PublicKey) error {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if method != jwt.SigningMethodRS256 {
+			break
+		}
+		if key.N.BitLen() < MIN_RSA_KEY_BITS {
+			return fmt.Errorf("RSA key must be at least %d bits", MIN_RSA_KEY_BITS)
+		}
+		return nil
+	case ed25519.PublicKey:
+		if method == jwt.SigningMethodEdDSA {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("key is not a %s key", method.Alg())
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/gomega"
+)
+
+const testRefreshSecret = "refresh-secret-with-at-least-32-chars"
+
+func writePrivateKey(t *testing.T, dir string, name string, key crypto.Signer) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write private key: %v", err)
+	}
+	return path
+}
+
+func writePublicKey(t *testing.T, dir string, name string, key crypto.PublicKey) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write public key: %v", err)
+	}
+}
+
+func testUser() *entity.UserWithProfile {
+	user := new(entity.UserWithProfile)
+	user.Id = 42
+	user.ProfileId = 7
+	user.UserType = entity.COLLECTOR
+	return user
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		JWT_REFRESH_TOKEN_SECRET:      testRefreshSecret,
+		ACCESS_TOKEN_EXPIRATION_TIME:  time.Minute,
+		REFRESH_TOKEN_EXPIRATION_TIME: time.Hour,
+	}
+}
+
+func TestAccessTokenKeys_EdDSARotation(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	verifyDir := t.TempDir()
+
+	_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+	writePublicKey(t, verifyDir, "2025-01.pem", oldKey.Public())
+
+	oldCfg := testConfig()
+	oldCfg.JWT_SIGNING_METHOD = "EdDSA"
+	oldCfg.JWT_SIGNING_KEY_ID = "2025-01"
+	oldCfg.JWT_SIGNING_KEY_PATH = writePrivateKey(t, dir, "old.pem", oldKey)
+	oldKeys, err := NewAccessTokenKeys(oldCfg)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cfg := testConfig()
+	cfg.JWT_SIGNING_METHOD = "EdDSA"
+	cfg.JWT_SIGNING_KEY_ID = "2025-02"
+	cfg.JWT_SIGNING_KEY_PATH = writePrivateKey(t, dir, "new.pem", newKey)
+	cfg.JWT_VERIFICATION_KEYS_DIR = verifyDir
+	keys, err := NewAccessTokenKeys(cfg)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	user, _, err := GenerateToken(testUser(), "family-1", keys, cfg)
+	g.Expect(err).ToNot(HaveOccurred())
+	token, _, _ := jwt.NewParser().ParseUnverified(user.AccessToken, new(JWTClaims))
+	g.Expect(token.Header).To(HaveKeyWithValue("kid", "2025-02"))
+	g.Expect(token.Header).To(HaveKeyWithValue("alg", "EdDSA"))
+
+	claims, err := ParseAccessToken(user.AccessToken, keys)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(claims.Subject).To(Equal("42"))
+
+	// token dari key lama tetap valid selama public key-nya masih terdaftar
+	oldUser, _, err := GenerateToken(testUser(), "family-1", oldKeys, oldCfg)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = ParseAccessToken(oldUser.AccessToken, keys)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// refresh token tetap HS256
+	_, err = ParseRefreshToken(user.RefreshToken, cfg)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	jwks := keys.JWKS()
+	g.Expect(jwks.Keys).To(HaveLen(2))
+	g.Expect(jwks.Keys[0].Kid).To(Equal("2025-01"))
+	g.Expect(jwks.Keys[0].Kty).To(Equal("OKP"))
+	g.Expect(jwks.Keys[0].Crv).To(Equal("Ed25519"))
+	g.Expect(jwks.Keys[1].Kid).To(Equal("2025-02"))
+}
+
+func TestAccessTokenKeys_RejectsUnknownKidAndAlgorithm(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cfg := testConfig()
+	cfg.JWT_SIGNING_METHOD = "RS256"
+	cfg.JWT_SIGNING_KEY_ID = "main"
+	cfg.JWT_SIGNING_KEY_PATH = writePrivateKey(t, dir, "rsa.pem", rsaKey)
+	keys, err := NewAccessTokenKeys(cfg)
+	g.Expect(err).ToNot(HaveOccurred())
+	jwks := keys.JWKS()
+	g.Expect(jwks.Keys).To(HaveLen(1))
+	g.Expect(jwks.Keys[0].Kty).To(Equal("RSA"))
+	g.Expect(jwks.Keys[0].E).To(Equal("AQAB"))
+
+	claims := JWTClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "42", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}}
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	unknown.Header["kid"] = "other"
+	unknownToken, _ := unknown.SignedString(rsaKey)
+	_, err = ParseAccessToken(unknownToken, keys)
+	g.Expect(err).To(MatchError(jwt.ErrTokenUnverifiable))
+
+	// token HS256 tidak boleh diterima pada mode asymmetric
+	hsToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("any-secret"))
+	_, err = ParseAccessToken(hsToken, keys)
+	g.Expect(err).To(MatchError(jwt.ErrTokenSignatureInvalid))
+}
+
+func TestNewAccessTokenKeys_KeyMismatch(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	cfg := testConfig()
+	cfg.JWT_SIGNING_METHOD = "RS256"
+	cfg.JWT_SIGNING_KEY_ID = "main"
+	cfg.JWT_SIGNING_KEY_PATH = writePrivateKey(t, dir, "ed.pem", edKey)
+
+	_, err := NewAccessTokenKeys(cfg)
+
+	g.Expect(err).To(MatchError(ContainSubstring("key is not a RS256 key")))
+}
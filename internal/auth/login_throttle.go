@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+)
+
+// LoginAttemptStore menyimpan hitungan login gagal per key.
+// repository.ILoginAttemptRepository adalah implementasi Postgres dari interface ini.
+type LoginAttemptStore interface {
+	Find(ctx context.Context, key string, now time.Time) Result[*entity.LoginAttempt]
+	Increment(ctx context.Context, key string, now time.Time, expiresAt time.Time) Result[int]
+	Lock(ctx context.Context, key string, until time.Time) Result[bool]
+	Reset(ctx context.Context, key string) Result[bool]
+}
+
+var _ LoginAttemptStore = (repository.ILoginAttemptRepository)(nil)
+
+// NewLoginAttemptStore memilih store sesuai LOGIN_ATTEMPT_STORE.
+// Store memory tidak dibagi antar instance sehingga batasnya berlaku per instance.
+func NewLoginAttemptStore(loginAttemptRepo repository.ILoginAttemptRepository, cfg *config.Config) LoginAttemptStore {
+	if cfg.LOGIN_ATTEMPT_STORE == "memory" {
+		return NewMemoryLoginAttemptStore()
+	}
+
+	return loginAttemptRepo
+}
+
+// MemoryLoginAttemptStore menyimpan hitungan login gagal di memory,
+// record yang sudah expired dihapus setiap kali ada kegagalan baru.
+type MemoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]entity.LoginAttempt
+}
+
+var _ LoginAttemptStore = (*MemoryLoginAttemptStore)(nil)
+
+func NewMemoryLoginAttemptStore() *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{attempts: make(map[string]entity.LoginAttempt)}
+}
+
+func (s *MemoryLoginAttemptStore) Find(ctx context.Context, key string, now time.Time) Result[*entity.LoginAttempt] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok || !now.Before(attempt.ExpiresAt) {
+		return Ok(&entity.LoginAttempt{AttemptKey: key})
+	}
+
+	return Ok(&attempt)
+}
+
+func (s *MemoryLoginAttemptStore) Increment(ctx context.Context, key string, now time.Time, expiresAt time.Time) Result[int] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+
+	attempt := s.attempts[key]
+	attempt.AttemptKey = key
+	attempt.Failures++
+	attempt.ExpiresAt = expiresAt
+	s.attempts[key] = attempt
+
+	return Ok(attempt.Failures)
+}
+
+func (s *MemoryLoginAttemptStore) Lock(ctx context.Context, key string, until time.Time) Result[bool] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		return Ok(true)
+	}
+
+	if attempt.LockedUntil == nil || until.After(*attempt.LockedUntil) {
+		attempt.LockedUntil = &until
+		s.attempts[key] = attempt
+	}
+
+	return Ok(true)
+}
+
+func (s *MemoryLoginAttemptStore) Reset(ctx context.Context, key string) Result[bool] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+
+	return Ok(true)
+}
+
+// prune harus dipanggil saat mu sedang dikunci
+func (s *MemoryLoginAttemptStore) prune(now time.Time) {
+	for key, attempt := range s.attempts {
+		if !now.Before(attempt.ExpiresAt) {
+			delete(s.attempts, key)
+		}
+	}
+}
+
+// LoginThrottle membatasi login gagal per akun (email) dan per IP.
+//
+// Setelah maxFailures kali gagal, key dikunci selama lockout. Setiap kegagalan berikutnya
+// setelah lockout selesai menggandakan lama lockout sampai maxLockout.
+// Hitungan dilupakan jika tidak ada kegagalan baru selama ttl.
+type LoginThrottle struct {
+	store         LoginAttemptStore
+	maxFailures   int
+	maxIpFailures int
+	lockout       time.Duration
+	maxLockout    time.Duration
+	ttl           time.Duration
+	now           func() time.Time
+}
+
+func NewLoginThrottle(store LoginAttemptStore, cfg *config.Config) *LoginThrottle {
+	return &LoginThrottle{
+		store:         store,
+		maxFailures:   cfg.LOGIN_MAX_FAILURES,
+		maxIpFailures: cfg.LOGIN_IP_MAX_FAILURES,
+		lockout:       cfg.LOGIN_LOCKOUT_DURATION,
+		maxLockout:    cfg.LOGIN_MAX_LOCKOUT_DURATION,
+		ttl:           cfg.LOGIN_FAILURE_TTL,
+		now:           time.Now,
+	}
+}
+
+// AccountKey menormalkan email supaya variasi huruf besar tidak menghasilkan hitungan terpisah
+func AccountKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func IPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// Check mengembalikan sisa lockout terlama dari akun dan IP, 0 jika login boleh dicoba
+func (t *LoginThrottle) Check(ctx context.Context, email string, ip string) Result[time.Duration] {
+	now := t.now()
+
+	var retryAfter time.Duration
+	for _, key := range []string{AccountKey(email), IPKey(ip)} {
+		result := t.store.Find(ctx, key, now)
+		if result.IsError() {
+			return Wrap[time.Duration](result, "Failed to check login attempts")
+		}
+
+		retryAfter = max(retryAfter, result.Value().RetryAfter(now))
+	}
+
+	return Ok(retryAfter)
+}
+
+// Failed mencatat login gagal untuk akun dan IP, lalu mengembalikan lama lockout
+// jika salah satunya mencapai batas
+func (t *LoginThrottle) Failed(ctx context.Context, email string, ip string) Result[time.Duration] {
+	now := t.now()
+
+	limits := []struct {
+		key   string
+		limit int
+	}{
+		{AccountKey(email), t.maxFailures},
+		{IPKey(ip), t.maxIpFailures},
+	}
+
+	var retryAfter time.Duration
+	for _, l := range limits {
+		failures := t.store.Increment(ctx, l.key, now, now.Add(t.ttl))
+		if failures.IsError() {
+			return Wrap[time.Duration](failures, "Failed to record login attempt")
+		}
+
+		lockout := t.backoff(failures.Value(), l.limit)
+		if lockout == 0 {
+			continue
+		}
+
+		if result := t.store.Lock(ctx, l.key, now.Add(lockout)); result.IsError() {
+			return Wrap[time.Duration](result, "Failed to lock login")
+		}
+		retryAfter = max(retryAfter, lockout)
+	}
+
+	return Ok(retryAfter)
+}
+
+// Succeeded menghapus hitungan gagal milik akun. Hitungan IP tetap disimpan
+// supaya penyerang tidak bisa meresetnya dengan login ke akunnya sendiri.
+func (t *LoginThrottle) Succeeded(ctx context.Context, email string) Result[bool] {
+	return t.store.Reset(ctx, AccountKey(email))
+}
+
+// Unlock menghapus hitungan gagal dan lockout untuk email dan/atau IP yang tidak kosong
+func (t *LoginThrottle) Unlock(ctx context.Context, email string, ip string) Result[bool] {
+	var keys []string
+	if email != "" {
+		keys = append(keys, AccountKey(email))
+	}
+	if ip != "" {
+		keys = append(keys, IPKey(ip))
+	}
+
+	for _, key := range keys {
+		if result := t.store.Reset(ctx, key); result.IsError() {
+			return result
+		}
+	}
+
+	return Ok(true)
+}
+
+// backoff menghitung lama lockout untuk jumlah gagal ke-failures, 0 jika belum mencapai limit
+func (t *LoginThrottle) backoff(failures int, limit int) time.Duration {
+	if failures < limit {
+		return 0
+	}
+
+	lockout := t.lockout
+	for range failures - limit {
+		if lockout >= t.maxLockout {
+			break
+		}
+		lockout *= 2
+	}
+
+	return min(lockout, t.maxLockout)
+}
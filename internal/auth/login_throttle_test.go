@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	. "github.com/onsi/gomega"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestThrottle() (*LoginThrottle, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 11, 26, 9, 0, 0, 0, time.UTC)}
+	throttle := NewLoginThrottle(NewMemoryLoginAttemptStore(), &config.Config{
+		LOGIN_MAX_FAILURES:         3,
+		LOGIN_IP_MAX_FAILURES:      5,
+		LOGIN_LOCKOUT_DURATION:     time.Minute,
+		LOGIN_MAX_LOCKOUT_DURATION: time.Minute * 4,
+		LOGIN_FAILURE_TTL:          time.Hour,
+	})
+	throttle.now = clock.Now
+
+	return throttle, clock
+}
+
+func TestLoginThrottle_LocksAccountWithBackoff(t *testing.T) {
+	g := NewWithT(t)
+	throttle, clock := newTestThrottle()
+	ctx := context.Background()
+
+	for range 2 {
+		g.Expect(throttle.Failed(ctx, "a@example.com", "10.0.0.1").Must()).To(BeZero())
+	}
+	g.Expect(throttle.Failed(ctx, "A@Example.com", "10.0.0.2").Must()).To(Equal(time.Minute))
+	g.Expect(throttle.Check(ctx, "a@example.com", "10.0.0.3").Must()).To(Equal(time.Minute))
+
+	// kegagalan setelah lockout selesai menggandakan lama lockout sampai batas maksimum
+	for _, want := range []time.Duration{time.Minute * 2, time.Minute * 4, time.Minute * 4} {
+		clock.now = clock.now.Add(throttle.Check(ctx, "a@example.com", "10.0.0.3").Must())
+		g.Expect(throttle.Check(ctx, "a@example.com", "10.0.0.3").Must()).To(BeZero())
+		g.Expect(throttle.Failed(ctx, "a@example.com", "10.0.0.3").Must()).To(Equal(want))
+	}
+}
+
+func TestLoginThrottle_LocksIP(t *testing.T) {
+	g := NewWithT(t)
+	throttle, _ := newTestThrottle()
+	ctx := context.Background()
+
+	emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"}
+	for _, email := range emails {
+		g.Expect(throttle.Failed(ctx, email, "10.0.0.1").Must()).To(BeZero())
+	}
+	g.Expect(throttle.Failed(ctx, "e@example.com", "10.0.0.1").Must()).To(Equal(time.Minute))
+
+	g.Expect(throttle.Check(ctx, "f@example.com", "10.0.0.1").Must()).To(Equal(time.Minute))
+	g.Expect(throttle.Check(ctx, "f@example.com", "10.0.0.2").Must()).To(BeZero())
+}
+
+func TestLoginThrottle_SucceededKeepsIPFailures(t *testing.T) {
+	g := NewWithT(t)
+	throttle, _ := newTestThrottle()
+	ctx := context.Background()
+
+	for range 4 {
+		throttle.Failed(ctx, "a@example.com", "10.0.0.1")
+		throttle.Succeeded(ctx, "a@example.com")
+	}
+
+	g.Expect(throttle.Failed(ctx, "a@example.com", "10.0.0.1").Must()).To(Equal(time.Minute))
+	g.Expect(throttle.Check(ctx, "b@example.com", "10.0.0.1").Must()).To(Equal(time.Minute))
+}
+
+func TestLoginThrottle_Unlock(t *testing.T) {
+	g := NewWithT(t)
+	throttle, _ := newTestThrottle()
+	ctx := context.Background()
+
+	for range 5 {
+		throttle.Failed(ctx, "a@example.com", "10.0.0.1")
+	}
+	g.Expect(throttle.Check(ctx, "a@example.com", "10.0.0.2").Must()).ToNot(BeZero())
+	g.Expect(throttle.Check(ctx, "b@example.com", "10.0.0.1").Must()).ToNot(BeZero())
+
+	g.Expect(throttle.Unlock(ctx, "A@example.com", "").Must()).To(BeTrue())
+	g.Expect(throttle.Check(ctx, "a@example.com", "10.0.0.2").Must()).To(BeZero())
+	g.Expect(throttle.Check(ctx, "b@example.com", "10.0.0.1").Must()).ToNot(BeZero())
+
+	g.Expect(throttle.Unlock(ctx, "", "10.0.0.1").Must()).To(BeTrue())
+	g.Expect(throttle.Check(ctx, "b@example.com", "10.0.0.1").Must()).To(BeZero())
+}
+
+func TestLoginThrottle_FailuresExpire(t *testing.T) {
+	g := NewWithT(t)
+	throttle, clock := newTestThrottle()
+	ctx := context.Background()
+
+	for range 2 {
+		throttle.Failed(ctx, "a@example.com", "10.0.0.1")
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	g.Expect(throttle.Failed(ctx, "a@example.com", "10.0.0.1").Must()).To(BeZero())
+}
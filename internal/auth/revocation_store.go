@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+)
+
+// RevocationStore menyimpan daftar token (jti) yang sudah dicabut.
+// repository.IRevokedTokenRepository adalah implementasi persisten dari interface ini.
+// RevokeUser mencabut semua token milik user dengan iat sebelum before.
+type RevocationStore interface {
+	Revoke(ctx context.Context, tokenId string, userId int64, expiresAt time.Time) Result[bool]
+	RevokeUser(ctx context.Context, userId int64, before time.Time) Result[bool]
+	IsRevoked(ctx context.Context, tokenId string, userId int64, issuedAt time.Time) Result[bool]
+}
+
+var _ RevocationStore = (repository.IRevokedTokenRepository)(nil)
+
+type revocationEntry struct {
+	userId  int64
+	revoked bool
+	until   time.Time
+}
+
+// CachedRevocationStore memasang cache in-memory di depan store lain supaya
+// Verify tidak perlu query ke database di setiap request.
+// Token yang dicabut disimpan selama revokedTtl (masa berlaku access token),
+// hasil negatif hanya disimpan selama ttl. Pencabutan dari instance lain (termasuk logout-all)
+// baru terlihat setelah hasil negatif di cache kadaluarsa, jadi ttl adalah batas keterlambatannya.
+type CachedRevocationStore struct {
+	backing    RevocationStore
+	ttl        time.Duration
+	revokedTtl time.Duration
+	mu         sync.Mutex
+	entries    map[string]revocationEntry
+}
+
+var _ RevocationStore = (*CachedRevocationStore)(nil)
+
+func NewCachedRevocationStore(backing RevocationStore, ttl time.Duration, revokedTtl time.Duration) *CachedRevocationStore {
+	return &CachedRevocationStore{
+		backing:    backing,
+		ttl:        ttl,
+		revokedTtl: revokedTtl,
+		entries:    make(map[string]revocationEntry),
+	}
+}
+
+func NewRevocationStore(revokedTokenRepo repository.IRevokedTokenRepository, cfg *config.Config) RevocationStore {
+	return NewCachedRevocationStore(revokedTokenRepo, cfg.REVOCATION_CACHE_TTL, cfg.ACCESS_TOKEN_EXPIRATION_TIME)
+}
+
+func (s *CachedRevocationStore) Revoke(ctx context.Context, tokenId string, userId int64, expiresAt time.Time) Result[bool] {
+	result := s.backing.Revoke(ctx, tokenId, userId, expiresAt)
+	if result.IsError() {
+		return result
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(time.Now())
+	s.entries[tokenId] = revocationEntry{userId: userId, revoked: true, until: expiresAt}
+
+	return result
+}
+
+func (s *CachedRevocationStore) RevokeUser(ctx context.Context, userId int64, before time.Time) Result[bool] {
+	result := s.backing.RevokeUser(ctx, userId, before)
+	if result.IsError() {
+		return result
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(time.Now())
+	for tokenId, entry := range s.entries {
+		if entry.userId == userId && !entry.revoked {
+			delete(s.entries, tokenId)
+		}
+	}
+
+	return result
+}
+
+func (s *CachedRevocationStore) IsRevoked(ctx context.Context, tokenId string, userId int64, issuedAt time.Time) Result[bool] {
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.entries[tokenId]
+	s.mu.Unlock()
+
+	if ok && now.Before(entry.until) {
+		return Ok(entry.revoked)
+	}
+
+	result := s.backing.IsRevoked(ctx, tokenId, userId, issuedAt)
+	if result.IsError() {
+		return result
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until := now.Add(s.ttl)
+	if result.Value() {
+		// token yang sudah dicabut tidak akan pernah valid lagi
+		until = now.Add(s.revokedTtl)
+	}
+	s.entries[tokenId] = revocationEntry{userId: userId, revoked: result.Value(), until: until}
+
+	return result
+}
+
+// prune harus dipanggil saat mu sedang dikunci
+func (s *CachedRevocationStore) prune(now time.Time) {
+	for tokenId, entry := range s.entries {
+		if !now.Before(entry.until) {
+			delete(s.entries, tokenId)
+		}
+	}
+}
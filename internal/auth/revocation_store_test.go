@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	. "github.com/onsi/gomega"
+)
+
+type fakeRevocationStore struct {
+	tokens  map[string]bool
+	users   map[int64]time.Time
+	lookups int
+}
+
+func newFakeRevocationStore() *fakeRevocationStore {
+	return &fakeRevocationStore{tokens: map[string]bool{}, users: map[int64]time.Time{}}
+}
+
+func (f *fakeRevocationStore) Revoke(ctx context.Context, tokenId string, userId int64, expiresAt time.Time) Result[bool] {
+	f.tokens[tokenId] = true
+	return Ok(true)
+}
+
+func (f *fakeRevocationStore) RevokeUser(ctx context.Context, userId int64, before time.Time) Result[bool] {
+	f.users[userId] = before
+	return Ok(true)
+}
+
+func (f *fakeRevocationStore) IsRevoked(ctx context.Context, tokenId string, userId int64, issuedAt time.Time) Result[bool] {
+	f.lookups++
+	if f.tokens[tokenId] {
+		return Ok(true)
+	}
+	before, ok := f.users[userId]
+	return Ok(ok && before.After(issuedAt))
+}
+
+func TestCachedRevocationStore_CachesNegativeLookups(t *testing.T) {
+	g := NewWithT(t)
+	backing := newFakeRevocationStore()
+	store := NewCachedRevocationStore(backing, time.Minute, time.Minute*15)
+	ctx := context.Background()
+
+	g.Expect(store.IsRevoked(ctx, "jti", 1, time.Now()).Value()).To(BeFalse())
+	g.Expect(store.IsRevoked(ctx, "jti", 1, time.Now()).Value()).To(BeFalse())
+	g.Expect(backing.lookups).To(Equal(1))
+}
+
+func TestCachedRevocationStore_RevokeOverridesCache(t *testing.T) {
+	g := NewWithT(t)
+	backing := newFakeRevocationStore()
+	store := NewCachedRevocationStore(backing, time.Minute, time.Minute*15)
+	ctx := context.Background()
+
+	g.Expect(store.IsRevoked(ctx, "jti", 1, time.Now()).Value()).To(BeFalse())
+
+	g.Expect(store.Revoke(ctx, "jti", 1, time.Now().Add(time.Minute)).IsError()).To(BeFalse())
+	g.Expect(store.IsRevoked(ctx, "jti", 1, time.Now()).Value()).To(BeTrue())
+	g.Expect(backing.lookups).To(Equal(1))
+}
+
+func TestCachedRevocationStore_RevokeUserInvalidatesCache(t *testing.T) {
+	g := NewWithT(t)
+	backing := newFakeRevocationStore()
+	store := NewCachedRevocationStore(backing, time.Minute, time.Minute*15)
+	ctx := context.Background()
+
+	issuedAt := time.Now().Add(-time.Minute)
+	g.Expect(store.IsRevoked(ctx, "a", 1, issuedAt).Value()).To(BeFalse())
+	g.Expect(store.IsRevoked(ctx, "b", 2, issuedAt).Value()).To(BeFalse())
+
+	g.Expect(store.RevokeUser(ctx, 1, time.Now()).IsError()).To(BeFalse())
+
+	g.Expect(store.IsRevoked(ctx, "a", 1, issuedAt).Value()).To(BeTrue())
+	g.Expect(store.IsRevoked(ctx, "b", 2, issuedAt).Value()).To(BeFalse())
+	g.Expect(backing.lookups).To(Equal(3))
+}
+
+func TestCachedRevocationStore_ExpiredEntriesAreRechecked(t *testing.T) {
+	g := NewWithT(t)
+	backing := newFakeRevocationStore()
+	store := NewCachedRevocationStore(backing, time.Nanosecond, time.Minute*15)
+	ctx := context.Background()
+
+	g.Expect(store.IsRevoked(ctx, "jti", 1, time.Now()).Value()).To(BeFalse())
+	time.Sleep(time.Millisecond)
+	g.Expect(store.IsRevoked(ctx, "jti", 1, time.Now()).Value()).To(BeFalse())
+	g.Expect(backing.lookups).To(Equal(2))
+}
@@ -1,6 +1,13 @@
 package constants
 
 const (
-	UserIdKey      = "userId"
-	CollectorIdKey = "collectorId"
+	UserIdKey         = "userId"
+	RoleKey           = "role"
+	ProfileIdKey      = "profileId"
+	CollectorIdKey    = "collectorId"
+	TokenIdKey        = "tokenId"
+	TokenExpiresAtKey = "tokenExpiresAt"
+	SessionIdKey      = "sessionId"
+	RefreshTokenIdKey = "refreshTokenId"
+	RequestIdKey      = "requestId"
 )
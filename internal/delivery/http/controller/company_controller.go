@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/middleware"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"github.com/crazydw4rf/oil-bank-backend/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	BASE_COMPANY_PATH       = config.BASE_API_HTTP_PATH + "/companies"
+	COMPANY_ME              = "/me"
+	COMPANY_ME_DELIVERIES   = "/me/deliveries"
+	COMPANY_ME_DELIVERY_ACK = "/me/deliveries/:id/acknowledge"
+	COMPANY_ME_SUMMARY      = "/me/summary"
+)
+
+type CompanyController struct {
+	companyUsecase usecase.ICompanyUsecase
+}
+
+func NewCompanyController(companyUsecase usecase.ICompanyUsecase) CompanyController {
+	return CompanyController{companyUsecase}
+}
+
+func (cc CompanyController) GetProfile(c *fiber.Ctx) error {
+	companyId := ProfileIdExtractor(c)
+	if companyId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_COMPANY_ID, true)
+	}
+
+	result := cc.companyUsecase.GetProfile(c.UserContext(), companyId.Value())
+	return Respond(c, fiber.StatusOK, result, i18n.COMPANY_PROFILE_FAILED)
+}
+
+func (cc CompanyController) GetDeliveries(c *fiber.Ctx) error {
+	companyId := ProfileIdExtractor(c)
+	if companyId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_COMPANY_ID, true)
+	}
+
+	req := dto.CompanyDeliveryRequest{}
+	if err := c.QueryParser(&req); err != nil {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_QUERY_PARAMETERS, true)
+	}
+
+	result := cc.companyUsecase.GetDeliveries(c.UserContext(), companyId.Value(), req)
+	return Respond(c, fiber.StatusOK, result, i18n.COMPANY_DELIVERIES_FAILED)
+}
+
+func (cc CompanyController) GetSummary(c *fiber.Ctx) error {
+	companyId := ProfileIdExtractor(c)
+	if companyId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_COMPANY_ID, true)
+	}
+
+	req := dto.CompanySummaryRequest{}
+	if err := c.QueryParser(&req); err != nil {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_QUERY_PARAMETERS, true)
+	}
+
+	result := cc.companyUsecase.GetSummary(c.UserContext(), companyId.Value(), req)
+	return Respond(c, fiber.StatusOK, result, i18n.COMPANY_SUMMARY_FAILED)
+}
+
+func (cc CompanyController) AcknowledgeDelivery(c *fiber.Ctx) error {
+	companyId := ProfileIdExtractor(c)
+	if companyId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_COMPANY_ID, true)
+	}
+
+	idStr := c.Params("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_DELIVERY_ID, true)
+	}
+
+	result := cc.companyUsecase.AcknowledgeDelivery(c.UserContext(), companyId.Value(), id)
+	return Respond(c, fiber.StatusOK, result, i18n.DELIVERY_ACKNOWLEDGE_FAILED)
+}
+
+func SetupCompanyRouter(app *fiber.App, ctrl CompanyController, mw middleware.HTTPMiddleware) {
+	app.Group(BASE_COMPANY_PATH, mw.Verify, mw.RequireRole(entity.COMPANY)).
+		Get(COMPANY_ME, ctrl.GetProfile).
+		Get(COMPANY_ME_DELIVERIES, ctrl.GetDeliveries).
+		Post(COMPANY_ME_DELIVERY_ACK, ctrl.AcknowledgeDelivery).
+		Get(COMPANY_ME_SUMMARY, ctrl.GetSummary)
+}
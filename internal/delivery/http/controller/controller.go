@@ -4,7 +4,10 @@ import (
 	"strconv"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/validator"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -35,3 +38,59 @@ func CollectorIdExtractor(c *fiber.Ctx) Result[int64] {
 
 	return Ok(id)
 }
+
+func RoleExtractor(c *fiber.Ctx) Result[entity.UserType] {
+	role, ok := c.Locals(constants.RoleKey).(string)
+	if !ok || !entity.UserType(role).IsValid() {
+		return NewError[entity.UserType]("Cannot extract user role").WithCause(INTERNAL_LOGIC_ERROR)
+	}
+
+	return Ok(entity.UserType(role))
+}
+
+// ProfileIdExtractor mengembalikan id Seller, Collector atau Company sesuai role user
+func ProfileIdExtractor(c *fiber.Ctx) Result[int64] {
+	sub, ok := c.Locals(constants.ProfileIdKey).(string)
+	if !ok {
+		return NewError[int64]("Cannot extract profile ID").WithCause(INTERNAL_LOGIC_ERROR)
+	}
+
+	id, err := strconv.ParseInt(sub, 10, 64)
+	if err != nil {
+		return NewError[int64]("Invalid profile ID").WithCause(INTERNAL_LOGIC_ERROR)
+	}
+
+	return Ok(id)
+}
+
+func RefreshTokenIdExtractor(c *fiber.Ctx) Result[string] {
+	tokenId, ok := c.Locals(constants.RefreshTokenIdKey).(string)
+	if !ok || tokenId == "" {
+		return NewError[string]("Cannot extract refresh token ID").WithCause(INTERNAL_LOGIC_ERROR)
+	}
+
+	return Ok(tokenId)
+}
+
+var (
+	errInvalidBody  = NewError[any]("Invalid request body", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_REQUEST_BODY).LastError()
+	errInvalidQuery = NewError[any]("Invalid query parameters", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_QUERY_PARAMETERS).LastError()
+)
+
+// ParseBody membaca body request ke dst lalu menjalankan validasi dari tag validate.
+// Error yang dikembalikan cukup diteruskan dari handler, ErrorHandler yang mengirimnya ke client.
+func ParseBody(c *fiber.Ctx, dst any) error {
+	if err := c.BodyParser(dst); err != nil {
+		return errInvalidBody
+	}
+
+	return validator.Validate(dst)
+}
+
+func ParseQuery(c *fiber.Ctx, dst any) error {
+	if err := c.QueryParser(dst); err != nil {
+		return errInvalidQuery
+	}
+
+	return validator.Validate(dst)
+}
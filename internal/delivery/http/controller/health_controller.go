@@ -0,0 +1,62 @@
+package controller
+
+import (
+	. "github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	HEALTH_LIVENESS_PATH  = "/healthz"
+	HEALTH_READINESS_PATH = "/readyz"
+)
+
+type HealthController struct {
+	health services.HealthService
+	drain  *services.DrainState
+}
+
+func NewHealthController(health services.HealthService, drain *services.DrainState) HealthController {
+	return HealthController{health, drain}
+}
+
+func (hc HealthController) Liveness(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(hc.health.Liveness())
+}
+
+// Readiness mengembalikan 503 jika salah satu pemeriksaan gagal supaya orchestrator
+// berhenti mengirim traffic ke instance ini
+func (hc HealthController) Readiness(c *fiber.Ctx) error {
+	resp := hc.health.Readiness(c.UserContext())
+
+	code := fiber.StatusOK
+	if resp.Status != dto.HEALTH_UP {
+		code = fiber.StatusServiceUnavailable
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(code).JSON(resp)
+}
+
+// RejectWhenDraining menolak request baru dengan 503 selama aplikasi dimatikan.
+// Connection: close dikirim supaya client membuka koneksi baru ke instance lain.
+func (hc HealthController) RejectWhenDraining(c *fiber.Ctx) error {
+	if hc.drain.IsDraining() {
+		c.Set(fiber.HeaderConnection, "close")
+		c.Set(fiber.HeaderRetryAfter, "1")
+		return NewHTTPErrorSimple(c, fiber.StatusServiceUnavailable, i18n.SERVICE_SHUTTING_DOWN, true)
+	}
+
+	return c.Next()
+}
+
+// SetupHealthRouter harus di-invoke sebelum router lain. RejectWhenDraining dipasang setelah
+// route probe sehingga hanya berlaku untuk route yang didaftarkan sesudahnya,
+// probe tetap bisa diakses selama draining.
+func SetupHealthRouter(app *fiber.App, ctrl HealthController) {
+	app.Get(HEALTH_LIVENESS_PATH, ctrl.Liveness)
+	app.Get(HEALTH_READINESS_PATH, ctrl.Readiness)
+	app.Use(ctrl.RejectWhenDraining)
+}
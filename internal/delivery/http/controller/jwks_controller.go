@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/crazydw4rf/oil-bank-backend/internal/auth"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+// JWKS_MAX_AGE dalam detik, cukup pendek supaya key baru cepat terlihat oleh service lain saat rotasi
+const JWKS_MAX_AGE = "max-age=300"
+
+type JWKSController struct {
+	keys *auth.AccessTokenKeys
+}
+
+func NewJWKSController(keys *auth.AccessTokenKeys) JWKSController {
+	return JWKSController{keys}
+}
+
+// GetJWKS mengirim public key untuk memverifikasi access token dalam format RFC 7517.
+// Pada mode HS256 daftar key kosong.
+func (jc JWKSController) GetJWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, JWKS_MAX_AGE)
+	return c.Status(fiber.StatusOK).JSON(jc.keys.JWKS())
+}
+
+func SetupJWKSRouter(app *fiber.App, ctrl JWKSController) {
+	app.Get(config.JWKS_PATH, ctrl.GetJWKS)
+}
@@ -1,11 +1,13 @@
 package controller
 
 import (
-	"log"
 	"strconv"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/middleware"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 	"github.com/crazydw4rf/oil-bank-backend/internal/usecase"
 	"github.com/gofiber/fiber/v2"
@@ -31,93 +33,58 @@ func (oc OilController) GetOil(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid oil ID", true)
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_OIL_ID, true)
 	}
 
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	result := oc.oilUsecase.GetOil(ctx, id)
-	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
-
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to get oil record", true)
-	}
-
-	return NewHTTPResponse(c, fiber.StatusOK, result.Value())
+	return Respond(c, fiber.StatusOK, result, i18n.OIL_GET_FAILED)
 }
 
 func (oc OilController) GetOilByCollectorId(c *fiber.Ctx) error {
 	res := CollectorIdExtractor(c)
 	if res.IsError() {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid collector ID", true)
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_COLLECTOR_ID, true)
 	}
 	collectorId := res.Value()
 
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	result := oc.oilUsecase.GetOilByCollectorId(ctx, collectorId)
-	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
-
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to get oil inventory", true)
-	}
-
-	return NewHTTPResponse(c, fiber.StatusOK, result.Value())
+	return Respond(c, fiber.StatusOK, result, i18n.OIL_INVENTORY_FAILED)
 }
 
 func (oc OilController) UpdateOil(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid oil ID", true)
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_OIL_ID, true)
 	}
 
-	req := struct {
-		TotalVolume float64 `json:"total_volume" validate:"gte=0"`
-	}{}
-
-	if err := c.BodyParser(&req); err != nil {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid request body", true)
+	req := new(dto.OilUpdateRequest)
+	if err := ParseBody(c, req); err != nil {
+		return err
 	}
 
-	ctx := c.Context()
-
-	result := oc.oilUsecase.UpdateOil(ctx, id, req.TotalVolume)
-	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
+	ctx := c.UserContext()
 
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to update oil record", true)
-	}
-
-	return NewHTTPResponse(c, fiber.StatusOK, result.Value())
+	result := oc.oilUsecase.UpdateOil(ctx, id, *req.TotalVolume)
+	return Respond(c, fiber.StatusOK, result, i18n.OIL_UPDATE_FAILED)
 }
 
 func (oc OilController) DeleteOil(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid oil ID", true)
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_OIL_ID, true)
 	}
 
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	result := oc.oilUsecase.DeleteOil(ctx, id)
 	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
-
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to delete oil record", true)
+		return ResultError(result, i18n.OIL_DELETE_FAILED)
 	}
 
 	return NewHTTPResponse(c, fiber.StatusOK, map[string]any{
@@ -126,7 +93,7 @@ func (oc OilController) DeleteOil(c *fiber.Ctx) error {
 }
 
 func SetupOilRouter(app *fiber.App, ctrl OilController, mw middleware.HTTPMiddleware) {
-	oilGroup := app.Group(BASE_OIL_PATH, mw.Verify)
+	oilGroup := app.Group(BASE_OIL_PATH, mw.Verify, mw.RequireRole(entity.COLLECTOR))
 
 	oilGroup.Get(OIL_GET, ctrl.GetOil)
 	oilGroup.Patch(OIL_UPDATE, ctrl.UpdateOil)
@@ -1,11 +1,11 @@
 package controller
 
 import (
-	"log"
-
 	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/middleware"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
 	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 	"github.com/crazydw4rf/oil-bank-backend/internal/usecase"
 	"github.com/gofiber/fiber/v2"
@@ -27,48 +27,30 @@ func NewReportController(reportUsecase usecase.IReportUsecase) ReportController
 
 func (rc ReportController) GetReportByDate(c *fiber.Ctx) error {
 	req := new(dto.ReportByDate)
-	if err := c.BodyParser(req); err != nil {
-		return fiber.ErrBadRequest
+	if err := ParseBody(c, req); err != nil {
+		return err
 	}
 
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	result := rc.reportUsecase.GetReportByDate(ctx, req)
-	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
-
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to get report", true)
-	}
-
-	return NewHTTPResponse(c, fiber.StatusOK, result.Value())
+	return Respond(c, fiber.StatusOK, result, i18n.REPORT_FAILED)
 }
 
 func (rc ReportController) GetAllReports(c *fiber.Ctx) error {
 	req := new(dto.ReportAll)
-	if err := c.BodyParser(req); err != nil {
-		return fiber.ErrBadRequest
+	if err := ParseBody(c, req); err != nil {
+		return err
 	}
 
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	result := rc.reportUsecase.GetAllReports(ctx, req)
-	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
-
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to get all reports", true)
-	}
-
-	return NewHTTPResponse(c, fiber.StatusOK, result.Value())
+	return Respond(c, fiber.StatusOK, result, i18n.REPORT_ALL_FAILED)
 }
 
 func SetupReportRouter(app *fiber.App, ctrl ReportController, mw middleware.HTTPMiddleware) {
-	app.Group(BASE_REPORT_PATH, mw.Verify).
+	app.Group(BASE_REPORT_PATH, mw.Verify, mw.RequireRole(entity.COLLECTOR)).
 		Post(REPORT_BY_DATE, ctrl.GetReportByDate).
 		Post(REPORT_ALL, ctrl.GetAllReports)
 }
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/middleware"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"github.com/crazydw4rf/oil-bank-backend/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	BASE_SELLER_PATH       = config.BASE_API_HTTP_PATH + "/sellers"
+	SELLER_ME              = "/me"
+	SELLER_ME_TRANSACTIONS = "/me/transactions"
+	SELLER_ME_SUMMARY      = "/me/summary"
+	SELLER_ME_MONTHLY      = "/me/summary/monthly"
+)
+
+type SellerController struct {
+	sellerUsecase usecase.ISellerUsecase
+}
+
+func NewSellerController(sellerUsecase usecase.ISellerUsecase) SellerController {
+	return SellerController{sellerUsecase}
+}
+
+func (sc SellerController) GetProfile(c *fiber.Ctx) error {
+	sellerId := ProfileIdExtractor(c)
+	if sellerId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_SELLER_ID, true)
+	}
+
+	result := sc.sellerUsecase.GetProfile(c.UserContext(), sellerId.Value())
+	return Respond(c, fiber.StatusOK, result, i18n.SELLER_PROFILE_FAILED)
+}
+
+func (sc SellerController) GetTransactions(c *fiber.Ctx) error {
+	sellerId := ProfileIdExtractor(c)
+	if sellerId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_SELLER_ID, true)
+	}
+
+	page := dto.PageRequest{}
+	if err := c.QueryParser(&page); err != nil {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_PAGINATION_PARAMETERS, true)
+	}
+
+	result := sc.sellerUsecase.GetTransactions(c.UserContext(), sellerId.Value(), page)
+	return Respond(c, fiber.StatusOK, result, i18n.SELLER_TRANSACTIONS_FAILED)
+}
+
+func (sc SellerController) GetSummary(c *fiber.Ctx) error {
+	sellerId := ProfileIdExtractor(c)
+	if sellerId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_SELLER_ID, true)
+	}
+
+	result := sc.sellerUsecase.GetSummary(c.UserContext(), sellerId.Value())
+	return Respond(c, fiber.StatusOK, result, i18n.SELLER_SUMMARY_FAILED)
+}
+
+func (sc SellerController) GetMonthlySummary(c *fiber.Ctx) error {
+	sellerId := ProfileIdExtractor(c)
+	if sellerId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_SELLER_ID, true)
+	}
+
+	req := dto.SellerMonthlyRequest{}
+	if err := c.QueryParser(&req); err != nil {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_YEAR, true)
+	}
+
+	result := sc.sellerUsecase.GetMonthlySummary(c.UserContext(), sellerId.Value(), req.Year)
+	return Respond(c, fiber.StatusOK, result, i18n.SELLER_MONTHLY_SUMMARY_FAILED)
+}
+
+func SetupSellerRouter(app *fiber.App, ctrl SellerController, mw middleware.HTTPMiddleware) {
+	app.Group(BASE_SELLER_PATH, mw.Verify, mw.RequireRole(entity.SELLER)).
+		Get(SELLER_ME, ctrl.GetProfile).
+		Get(SELLER_ME_TRANSACTIONS, ctrl.GetTransactions).
+		Get(SELLER_ME_SUMMARY, ctrl.GetSummary).
+		Get(SELLER_ME_MONTHLY, ctrl.GetMonthlySummary)
+}
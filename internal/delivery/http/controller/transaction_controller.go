@@ -1,11 +1,11 @@
 package controller
 
 import (
-	"log"
-
 	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/middleware"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
 	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 	"github.com/crazydw4rf/oil-bank-backend/internal/usecase"
 	"github.com/gofiber/fiber/v2"
@@ -14,7 +14,12 @@ import (
 const (
 	BASE_TRANSACTION_PATH = config.BASE_API_HTTP_PATH + "/transactions"
 	TRANSACTION_CREATE    = BASE_TRANSACTION_PATH
+	TRANSACTION_LIST      = BASE_TRANSACTION_PATH
 	TRANSACTION_UPDATE    = BASE_TRANSACTION_PATH + "/:id"
+	TRANSACTION_CANCEL    = BASE_TRANSACTION_PATH + "/:id/cancel"
+	TRANSACTION_SYNC      = BASE_TRANSACTION_PATH + "/sync"
+
+	IDEMPOTENT_REPLAYED_HEADER_NAME = "Idempotent-Replayed"
 )
 
 type TransactionController struct {
@@ -27,72 +32,124 @@ func NewTransactionController(transactionUsecase usecase.ITransactionUsecase) Tr
 
 func (tc TransactionController) CreateTransaction(c *fiber.Ctx) error {
 	req := new(dto.TransactionCreateDto)
-	if err := c.BodyParser(req); err != nil {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid request body", true)
+	if err := ParseBody(c, req); err != nil {
+		return err
 	}
 
 	var res = CollectorIdExtractor(c)
 	if res.IsError() {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Collector ID not found", true)
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.COLLECTOR_ID_NOT_FOUND, true)
 	}
 	collectorId := res.Value()
 
-	ctx := c.Context()
+	ctx := c.UserContext()
+
+	if key := c.Get(config.IDEMPOTENCY_KEY_HEADER_NAME); key != "" {
+		return tc.createTransactionIdempotent(c, collectorId, key, req)
+	}
 
 	result := tc.transactionUsecase.CreateTransaction(ctx, collectorId, req)
+	return Respond(c, fiber.StatusCreated, result, i18n.TRANSACTION_CREATE_FAILED)
+}
+
+func (tc TransactionController) createTransactionIdempotent(c *fiber.Ctx, collectorId int64, key string, req *dto.TransactionCreateDto) error {
+	result := tc.transactionUsecase.CreateTransactionIdempotent(c.UserContext(), collectorId, key, req)
 	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
+		return ResultError(result, i18n.TRANSACTION_CREATE_FAILED)
+	}
 
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to create transaction", true)
+	if result.Value().Replayed {
+		c.Set(IDEMPOTENT_REPLAYED_HEADER_NAME, "true")
 	}
 
-	return NewHTTPResponse(c, fiber.StatusCreated, result.Value())
+	return NewHTTPResponse(c, fiber.StatusCreated, result.Value().Value)
 }
 
 func (tc TransactionController) UpdateTransaction(c *fiber.Ctx) error {
 	// Parse transaction ID from URL parameter
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid transaction ID", true)
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_TRANSACTION_ID, true)
 	}
 
-	// Parse request body
+	// Parse and validate request body
 	req := new(dto.UpdateTransactionDto)
-	if err := c.BodyParser(req); err != nil {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid request body", true)
-	}
-
-	// Validate transaction type
-	if req.TransactionType != dto.TRANSACTION_SELL && req.TransactionType != dto.TRANSACTION_BUY {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid transaction type. Must be SELL or BUY", true)
+	if err := ParseBody(c, req); err != nil {
+		return err
 	}
 
 	// Extract collector ID from context
 	var res = CollectorIdExtractor(c)
 	if res.IsError() {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Collector ID not found", true)
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.COLLECTOR_ID_NOT_FOUND, true)
 	}
 	collectorId := res.Value()
 
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	result := tc.transactionUsecase.UpdateTransaction(ctx, collectorId, int64(id), req)
-	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
+	return Respond(c, fiber.StatusOK, result, i18n.TRANSACTION_UPDATE_FAILED)
+}
+
+func (tc TransactionController) CancelTransaction(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_TRANSACTION_ID, true)
+	}
 
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to update transaction", true)
+	req := new(dto.CancelTransactionDto)
+	if err := ParseBody(c, req); err != nil {
+		return err
 	}
 
-	return NewHTTPResponse(c, fiber.StatusOK, result.Value())
+	var res = CollectorIdExtractor(c)
+	if res.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.COLLECTOR_ID_NOT_FOUND, true)
+	}
+	collectorId := res.Value()
+
+	result := tc.transactionUsecase.CancelTransaction(c.UserContext(), collectorId, int64(id), req)
+	return Respond(c, fiber.StatusOK, result, i18n.TRANSACTION_CANCEL_FAILED)
+}
+
+func (tc TransactionController) SyncTransactions(c *fiber.Ctx) error {
+	req := new(dto.SyncTransactionRequest)
+	if err := ParseBody(c, req); err != nil {
+		return err
+	}
+
+	var res = CollectorIdExtractor(c)
+	if res.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.COLLECTOR_ID_NOT_FOUND, true)
+	}
+	collectorId := res.Value()
+
+	result := tc.transactionUsecase.SyncTransactions(c.UserContext(), collectorId, req)
+	return Respond(c, fiber.StatusOK, result, i18n.TRANSACTION_SYNC_FAILED)
+}
+
+func (tc TransactionController) ListTransactions(c *fiber.Ctx) error {
+	req := dto.TransactionListRequest{}
+	if err := ParseQuery(c, &req); err != nil {
+		return err
+	}
+
+	var res = CollectorIdExtractor(c)
+	if res.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.COLLECTOR_ID_NOT_FOUND, true)
+	}
+	collectorId := res.Value()
+
+	result := tc.transactionUsecase.ListTransactions(c.UserContext(), collectorId, req)
+	return Respond(c, fiber.StatusOK, result, i18n.TRANSACTION_LIST_FAILED)
 }
 
 func SetupTransactionRouter(app *fiber.App, ctrl TransactionController, mw middleware.HTTPMiddleware) {
-	app.Post(TRANSACTION_CREATE, mw.Verify, ctrl.CreateTransaction)
-	app.Patch(TRANSACTION_UPDATE, mw.Verify, ctrl.UpdateTransaction)
+	collectorOnly := mw.RequireRole(entity.COLLECTOR)
+
+	app.Get(TRANSACTION_LIST, mw.Verify, collectorOnly, ctrl.ListTransactions)
+	app.Post(TRANSACTION_CREATE, mw.Verify, collectorOnly, ctrl.CreateTransaction)
+	app.Post(TRANSACTION_SYNC, mw.Verify, collectorOnly, ctrl.SyncTransactions)
+	app.Patch(TRANSACTION_UPDATE, mw.Verify, collectorOnly, ctrl.UpdateTransaction)
+	app.Post(TRANSACTION_CANCEL, mw.Verify, collectorOnly, ctrl.CancelTransaction)
 }
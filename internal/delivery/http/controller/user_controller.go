@@ -1,14 +1,18 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/crazydw4rf/oil-bank-backend/internal/auth"
+	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
 	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/middleware"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
 	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 	"github.com/crazydw4rf/oil-bank-backend/internal/usecase"
 	"github.com/gofiber/fiber/v2"
@@ -17,86 +21,149 @@ import (
 const (
 	BASE_USER_PATH = config.BASE_API_HTTP_PATH + "/users"
 	// Group /users
-	USER_GETMANY  = "/"
-	USER_GET      = "/:id"
-	USER_UPDATE   = "/:id"
-	USER_DELETE   = "/:id"
-	USER_CREATE   = BASE_USER_PATH + "/"
-	USER_LOGIN    = BASE_USER_PATH + "/auth/login"
-	REFRESH_TOKEN = BASE_USER_PATH + "/auth/refresh"
+	USER_GETMANY    = "/"
+	USER_GET        = "/:id"
+	USER_UPDATE     = "/:id"
+	USER_DELETE     = "/:id"
+	USER_CREATE     = BASE_USER_PATH + "/"
+	USER_AUTH       = BASE_USER_PATH + "/auth"
+	USER_LOGIN      = USER_AUTH + "/login"
+	USER_LOGOUT     = USER_AUTH + "/logout"
+	USER_LOGOUT_ALL = USER_AUTH + "/logout-all"
+	REFRESH_TOKEN   = USER_AUTH + "/refresh"
+	USER_UNLOCK     = USER_AUTH + "/unlock"
 )
 
 type UserController struct {
 	userUsecase usecase.IUserUsecase
+	cfg         *config.Config
 }
 
-func NewUserController(userUsecase usecase.IUserUsecase) UserController {
-	return UserController{userUsecase}
+func NewUserController(userUsecase usecase.IUserUsecase, cfg *config.Config) UserController {
+	return UserController{userUsecase, cfg}
 }
 
 func (uc UserController) UserCreate(c *fiber.Ctx) error {
 	req := new(dto.UserCreateRequest)
-	if err := c.BodyParser(req); err != nil {
-		return fiber.ErrBadRequest
+	if err := ParseBody(c, req); err != nil {
+		return err
 	}
 
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	result := uc.userUsecase.UserRegister(ctx, req)
-	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
-
-		log.Println(result)
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to create user", true)
-	}
-
 	// FIXME: untuk membuat pengguna baru tidak perlu mengembalikan data pengguna
-	return NewHTTPResponse(c, fiber.StatusCreated, result.Value())
+	return Respond(c, fiber.StatusCreated, result, i18n.USER_CREATE_FAILED)
 }
 
 func (uc UserController) UserLogin(c *fiber.Ctx) error {
 	req := new(dto.UserLoginRequest)
-	if err := c.BodyParser(req); err != nil {
-		fmt.Println(err)
-		return fiber.ErrBadRequest
+	if err := ParseBody(c, req); err != nil {
+		return err
 	}
 
-	ctx := c.Context()
+	ctx := c.UserContext()
 
-	result := uc.userUsecase.UserLogin(ctx, req)
+	result := uc.userUsecase.UserLogin(ctx, c.IP(), req)
 	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
+		if err := result.ExpectedError(); err != nil && errors.Is(err, TOO_MANY_REQUESTS_ERROR) {
+			c.Set(fiber.HeaderRetryAfter, fmt.Sprint(err.Fields()[usecase.LOGIN_RETRY_AFTER_FIELD]))
 		}
+		return ResultError(result, i18n.LOGIN_FAILED)
+	}
+
+	user := result.Value()
+
+	setAuthCookie(user, c, uc.cfg)
+
+	return NewHTTPResponse(c, fiber.StatusOK, user)
+}
+
+// UserUnlock menghapus lockout login, hanya bisa dipanggil dengan ADMIN_API_KEY
+func (uc UserController) UserUnlock(c *fiber.Ctx) error {
+	req := new(dto.UserUnlockRequest)
+	if err := ParseBody(c, req); err != nil {
+		return err
+	}
+
+	result := uc.userUsecase.UserUnlock(c.UserContext(), req)
+	if result.IsError() {
+		return ResultError(result, i18n.LOGIN_UNLOCK_FAILED)
+	}
+
+	return NewHTTPResponse(c, fiber.StatusOK, map[string]any{
+		"message": "Login unlocked successfully",
+	})
+}
 
-		// TODO: pake zap library untuk logging
-		log.Println(result)
+func (uc UserController) RefreshToken(c *fiber.Ctx) error {
+	userId := UserIdExtractor(c)
+	if userId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.INVALID_REFRESH_TOKEN, true)
+	}
 
-		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to login user", true)
+	tokenId := RefreshTokenIdExtractor(c)
+	if tokenId.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.INVALID_REFRESH_TOKEN, true)
+	}
+
+	ctx := c.UserContext()
+
+	result := uc.userUsecase.UserRefreshToken(ctx, userId.Value(), tokenId.Value())
+	if result.IsError() {
+		return ResultError(result, i18n.REFRESH_TOKEN_FAILED)
 	}
 
 	user := result.Value()
 
-	setAuthCookie(user, c)
+	setAuthCookie(user, c, uc.cfg)
 
 	return NewHTTPResponse(c, fiber.StatusOK, user)
 }
 
-func (uc UserController) GetUser(c *fiber.Ctx) error {
-	id := UserIdExtractor(c)
-	if id.IsError() {
-		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid user id", true)
+func (uc UserController) UserLogout(c *fiber.Ctx) error {
+	session := sessionExtractor(c, uc.cfg)
+	if session.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.INVALID_SESSION, true)
 	}
-	result := uc.userUsecase.UserFind(c.Context(), id.Value())
+
+	result := uc.userUsecase.UserLogout(c.UserContext(), session.Value())
 	if result.IsError() {
-		if err := result.ExpectedError(); err != nil {
-			return NewHTTPError(c, err)
-		}
+		return ResultError(result, i18n.LOGOUT_FAILED)
+	}
+
+	clearAuthCookie(c, uc.cfg)
+
+	return NewHTTPResponse(c, fiber.StatusOK, map[string]any{
+		"message": "Logged out successfully",
+	})
+}
+
+func (uc UserController) UserLogoutAll(c *fiber.Ctx) error {
+	session := sessionExtractor(c, uc.cfg)
+	if session.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.INVALID_SESSION, true)
 	}
 
-	return NewHTTPResponse(c, fiber.StatusOK, result.Value())
+	result := uc.userUsecase.UserLogoutAll(c.UserContext(), session.Value())
+	if result.IsError() {
+		return ResultError(result, i18n.LOGOUT_ALL_FAILED)
+	}
+
+	clearAuthCookie(c, uc.cfg)
+
+	return NewHTTPResponse(c, fiber.StatusOK, map[string]any{
+		"message": "Logged out from all devices successfully",
+	})
+}
+
+func (uc UserController) GetUser(c *fiber.Ctx) error {
+	id := UserIdExtractor(c)
+	if id.IsError() {
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.INVALID_USER_ID, true)
+	}
+	result := uc.userUsecase.UserFind(c.UserContext(), id.Value())
+	return Respond(c, fiber.StatusOK, result, i18n.USER_GET_FAILED)
 }
 
 func (uc UserController) GetUserMany(c *fiber.Ctx) error {
@@ -111,28 +178,89 @@ func (uc UserController) DeleteUser(c *fiber.Ctx) error {
 	panic("Not implemented")
 }
 
-func setAuthCookie(user *entity.UserWithCollector, ctx *fiber.Ctx) {
+func setAuthCookie(user *entity.UserWithProfile, ctx *fiber.Ctx, cfg *config.Config) {
 	ctx.Cookie(&fiber.Cookie{
-		Name:     config.ACCESS_TOKEN_COOKIE_NAME,
+		Name:     cfg.ACCESS_TOKEN_COOKIE_NAME,
 		Value:    user.AccessToken,
 		Path:     "/",
-		Expires:  time.Now().Add(config.ACCESS_TOKEN_EXPIRATION_TIME),
+		Expires:  time.Now().Add(cfg.ACCESS_TOKEN_EXPIRATION_TIME),
 		HTTPOnly: true,
 		Secure:   true,
 	})
 	ctx.Cookie(&fiber.Cookie{
-		Name:     config.REFRESH_TOKEN_COOKIE_NAME,
+		Name:     cfg.REFRESH_TOKEN_COOKIE_NAME,
 		Value:    user.RefreshToken,
-		Path:     REFRESH_TOKEN,
-		Expires:  time.Now().Add(config.REFRESH_TOKEN_EXPIRATION_TIME),
+		Path:     USER_AUTH,
+		Expires:  time.Now().Add(cfg.REFRESH_TOKEN_EXPIRATION_TIME),
+		HTTPOnly: true,
+		Secure:   true,
+	})
+}
+
+func clearAuthCookie(ctx *fiber.Ctx, cfg *config.Config) {
+	ctx.Cookie(&fiber.Cookie{
+		Name:     cfg.ACCESS_TOKEN_COOKIE_NAME,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
 		HTTPOnly: true,
 		Secure:   true,
 	})
+	ctx.Cookie(&fiber.Cookie{
+		Name:     cfg.REFRESH_TOKEN_COOKIE_NAME,
+		Value:    "",
+		Path:     USER_AUTH,
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HTTPOnly: true,
+		Secure:   true,
+	})
+}
+
+// sessionExtractor mengumpulkan informasi sesi dari token yang sudah diverifikasi.
+// Family refresh token diambil dari claim sid, refresh token dari cookie hanya dibaca jika ada dan valid.
+func sessionExtractor(c *fiber.Ctx, cfg *config.Config) Result[*dto.UserSession] {
+	userId := UserIdExtractor(c)
+	if userId.IsError() {
+		return NewError[*dto.UserSession]("Cannot extract user ID").WithCause(INTERNAL_LOGIC_ERROR)
+	}
+
+	tokenId, ok := c.Locals(constants.TokenIdKey).(string)
+	if !ok || tokenId == "" {
+		return NewError[*dto.UserSession]("Cannot extract token ID").WithCause(INTERNAL_LOGIC_ERROR)
+	}
+
+	expiresAt, ok := c.Locals(constants.TokenExpiresAtKey).(time.Time)
+	if !ok {
+		return NewError[*dto.UserSession]("Cannot extract token expiration").WithCause(INTERNAL_LOGIC_ERROR)
+	}
+
+	session := &dto.UserSession{
+		UserId:    userId.Value(),
+		TokenId:   tokenId,
+		ExpiresAt: expiresAt,
+	}
+	session.FamilyId, _ = c.Locals(constants.SessionIdKey).(string)
+
+	if rt := c.Cookies(cfg.REFRESH_TOKEN_COOKIE_NAME); rt != "" {
+		if claims, err := auth.ParseRefreshToken(rt, cfg); err == nil {
+			session.RefreshTokenId = claims.ID
+		}
+	}
+
+	return Ok(session)
 }
 
 func SetupUserRouter(app *fiber.App, ctrl UserController, mw middleware.HTTPMiddleware) {
 	app.Post(USER_CREATE, ctrl.UserCreate)
 	app.Post(USER_LOGIN, ctrl.UserLogin)
+	app.Post(REFRESH_TOKEN, mw.VerifyRefreshToken, ctrl.RefreshToken)
+	app.Post(USER_LOGOUT, mw.Verify, ctrl.UserLogout)
+	app.Post(USER_LOGOUT_ALL, mw.Verify, ctrl.UserLogoutAll)
+	if ctrl.cfg.ADMIN_API_KEY != "" {
+		app.Post(USER_UNLOCK, mw.RequireAdminKey, ctrl.UserUnlock)
+	}
 
 	app.Group(BASE_USER_PATH, mw.Verify).
 		Get(USER_GET, ctrl.GetUser).
@@ -1,64 +1,162 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/crazydw4rf/oil-bank-backend/internal/auth"
 	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
-	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 func (m HTTPMiddleware) Verify(c *fiber.Ctx) error {
-	var tokenString string
+	tokenString, err := extractAccessToken(c, m.cfg)
+	if err != nil {
+		return tokenErrorResponse(c, err)
+	}
 
-	// ambil token dari cookie
-	tokenString = c.Cookies("token")
-	if tokenString == "" {
-		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, "Missing token")
+	claims, err := auth.ParseAccessToken(tokenString, m.keys)
+	if err != nil {
+		return tokenErrorResponse(c, err)
+	}
+
+	userId, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || claims.ID == "" || claims.IssuedAt == nil {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.TOKEN_INVALID_CLAIMS, true)
+	}
+
+	revoked := m.revocations.IsRevoked(c.UserContext(), claims.ID, userId, claims.IssuedAt.Time)
+	if revoked.IsError() {
+		logger.FromContext(c.UserContext()).Error("failed to check token revocation", "error", revoked)
+		return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, i18n.TOKEN_VERIFICATION_FAILED)
+	}
+	if revoked.Value() {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.TOKEN_REVOKED, true)
+	}
+
+	c.Locals(constants.UserIdKey, claims.Subject)
+	c.Locals(constants.RoleKey, string(claims.Role))
+	c.Locals(constants.ProfileIdKey, claims.ProfileID)
+	if claims.Role == entity.COLLECTOR {
+		c.Locals(constants.CollectorIdKey, claims.ProfileID)
+		logger.With(c, "collector_id", claims.ProfileID)
 	}
-	// else if tokenHeader := c.Get(config.ACCESS_TOKEN_HEADER_NAME); tokenHeader != "" {
-	// 	tokenString = strings.TrimPrefix(tokenHeader, "Bearer ")
-	// 	if tokenString == tokenHeader {
-	// 		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Invalid token")
-	// 	}
-	// } else {
-	// 	return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, "Missing token")
-	// }
-
-	claims := new(auth.JWTClaims)
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
-		if t.Header["alg"] != "HS256" {
-			return nil, jwt.ErrSignatureInvalid
+	c.Locals(constants.TokenIdKey, claims.ID)
+	c.Locals(constants.TokenExpiresAtKey, claims.ExpiresAt.Time)
+	c.Locals(constants.SessionIdKey, claims.SessionID)
+
+	logger.With(c, "user_id", claims.Subject, "role", string(claims.Role))
+
+	return c.Next()
+}
+
+// RequireRole hanya meneruskan request dari user dengan salah satu role yang diberikan.
+// Harus dipasang setelah Verify.
+func (m HTTPMiddleware) RequireRole(roles ...entity.UserType) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, ok := c.Locals(constants.RoleKey).(string)
+		if !ok || role == "" {
+			return NewHTTPErrorSimple(c, fiber.StatusForbidden, i18n.MISSING_ROLE, true)
 		}
 
-		return []byte(m.cfg.JWT_ACCESS_TOKEN_SECRET), nil
-	})
-	// FIXME: buat centralized error handling
-	if err != nil {
-		switch err {
-		case jwt.ErrTokenExpired:
-			return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, "Token expired")
-		case jwt.ErrSignatureInvalid:
-		case jwt.ErrTokenMalformed:
-			return NewHTTPErrorSimple(c, fiber.StatusBadRequest, "Token has invalid signature or malformed")
-		default:
-			return NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Unknown error")
+		if slices.Contains(roles, entity.UserType(role)) {
+			return c.Next()
 		}
+
+		return NewHTTPErrorSimple(c, fiber.StatusForbidden, i18n.PERMISSION_DENIED, true)
+	}
+}
+
+func (m HTTPMiddleware) VerifyRefreshToken(c *fiber.Ctx) error {
+	tokenString := c.Cookies(m.cfg.REFRESH_TOKEN_COOKIE_NAME)
+	if tokenString == "" {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.MISSING_REFRESH_TOKEN, true)
 	}
 
-	sub, err := token.Claims.GetSubject()
+	claims, err := auth.ParseRefreshToken(tokenString, m.cfg)
 	if err != nil {
-		return response.NewHTTPErrorSimple(c, fiber.StatusInternalServerError, "Failed to get subject from token")
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.REFRESH_TOKEN_EXPIRED, true)
+		}
+
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.INVALID_REFRESH_TOKEN, true)
 	}
 
-	c.Locals(constants.UserIdKey, sub)
-	c.Locals(constants.CollectorIdKey, claims.CollectorID)
+	if claims.Subject == "" || claims.ID == "" {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.INVALID_REFRESH_TOKEN, true)
+	}
+
+	c.Locals(constants.UserIdKey, claims.Subject)
+	c.Locals(constants.RefreshTokenIdKey, claims.ID)
+	logger.With(c, "user_id", claims.Subject)
 
 	return c.Next()
 }
 
-func (m HTTPMiddleware) VerifyRefreshToken(c *fiber.Ctx) error {
-	// TODO: implementasi logika refresh token
+var (
+	errMissingToken           = errors.New("missing token")
+	errMalformedAuthorization = errors.New("malformed authorization header")
+)
+
+// extractAccessToken membaca token dari header Authorization (Bearer) terlebih dahulu,
+// lalu dari cookie jika header tidak dikirim
+func extractAccessToken(c *fiber.Ctx, cfg *config.Config) (string, error) {
+	if header := c.Get(config.ACCESS_TOKEN_HEADER_NAME); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			return "", errMalformedAuthorization
+		}
+
+		token = strings.TrimSpace(token)
+		if token == "" {
+			return "", errMalformedAuthorization
+		}
+
+		return token, nil
+	}
+
+	if token := c.Cookies(cfg.ACCESS_TOKEN_COOKIE_NAME); token != "" {
+		return token, nil
+	}
+
+	return "", errMissingToken
+}
+
+func tokenErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errMissingToken):
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.MISSING_TOKEN, true)
+	case errors.Is(err, errMalformedAuthorization):
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.MALFORMED_AUTHORIZATION_HEADER, true)
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return NewHTTPErrorSimple(c, fiber.StatusBadRequest, i18n.TOKEN_MALFORMED, true)
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.TOKEN_EXPIRED, true)
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.TOKEN_INVALID_SIGNATURE, true)
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.TOKEN_NOT_VALID_YET, true)
+	default:
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.TOKEN_INVALID_CLAIMS, true)
+	}
+}
+
+// RequireAdminKey hanya meneruskan request dengan header X-Admin-Key yang sama dengan ADMIN_API_KEY.
+// Perbandingan dilakukan dalam waktu konstan supaya key tidak bisa ditebak dari waktu respons.
+func (m HTTPMiddleware) RequireAdminKey(c *fiber.Ctx) error {
+	key := c.Get(config.ADMIN_KEY_HEADER_NAME)
+	if m.cfg.ADMIN_API_KEY == "" || subtle.ConstantTimeCompare([]byte(key), []byte(m.cfg.ADMIN_API_KEY)) != 1 {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.INVALID_ADMIN_KEY, true)
+	}
+
 	return c.Next()
 }
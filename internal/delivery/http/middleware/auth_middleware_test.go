@@ -0,0 +1,315 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/auth"
+	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/gomega"
+)
+
+const testAccessSecret = "access-secret-for-tests"
+
+type fakeRevocationStore struct {
+	revoked bool
+	fail    bool
+}
+
+func (f fakeRevocationStore) Revoke(ctx context.Context, tokenId string, userId int64, expiresAt time.Time) Result[bool] {
+	return Ok(true)
+}
+
+func (f fakeRevocationStore) RevokeUser(ctx context.Context, userId int64, before time.Time) Result[bool] {
+	return Ok(true)
+}
+
+func (f fakeRevocationStore) IsRevoked(ctx context.Context, tokenId string, userId int64, issuedAt time.Time) Result[bool] {
+	if f.fail {
+		return NewError[bool]("database error").WithCause(INTERNAL_SERVICE_ERROR)
+	}
+	return Ok(f.revoked)
+}
+
+func setupVerifyApp(store auth.RevocationStore) *fiber.App {
+	cfg := &config.Config{JWT_ACCESS_TOKEN_SECRET: testAccessSecret, ACCESS_TOKEN_COOKIE_NAME: config.DEFAULT_ACCESS_TOKEN_COOKIE_NAME}
+	keys, err := auth.NewAccessTokenKeys(cfg)
+	if err != nil {
+		panic(err)
+	}
+	mw := NewHTTPMiddleware(cfg, keys, store)
+
+	app := fiber.New()
+	app.Get("/", mw.Verify, func(c *fiber.Ctx) error {
+		return c.SendString(fmt.Sprintf("%v:%v", c.Locals(constants.UserIdKey), c.Locals(constants.CollectorIdKey)))
+	})
+	app.Get("/collector", mw.Verify, mw.RequireRole(entity.COLLECTOR), func(c *fiber.Ctx) error {
+		return c.SendString("collector")
+	})
+	app.Get("/partner", mw.Verify, mw.RequireRole(entity.SELLER, entity.COMPANY), func(c *fiber.Ctx) error {
+		return c.SendString(fmt.Sprintf("%v:%v", c.Locals(constants.RoleKey), c.Locals(constants.ProfileIdKey)))
+	})
+
+	return app
+}
+
+func validClaims() auth.JWTClaims {
+	now := time.Now()
+	return auth.JWTClaims{
+		Role:      entity.COLLECTOR,
+		ProfileID: "7",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "0d5c8f0e-0a57-4a4f-9d0b-7c2f4f6b1e11",
+			Subject:   "42",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+	}
+}
+
+func signToken(t *testing.T, claims auth.JWTClaims, method jwt.SigningMethod, secret string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func doVerify(t *testing.T, app *fiber.App, header string, cookie string) (int, string) {
+	t.Helper()
+	return doRequest(t, app, "/", header, cookie)
+}
+
+func doRequest(t *testing.T, app *fiber.App, path string, header string, cookie string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if header != "" {
+		req.Header.Set(config.ACCESS_TOKEN_HEADER_NAME, header)
+	}
+	if cookie != "" {
+		req.AddCookie(&http.Cookie{Name: config.DEFAULT_ACCESS_TOKEN_COOKIE_NAME, Value: cookie})
+	}
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	return res.StatusCode, string(body)
+}
+
+func TestVerify_BearerHeader(t *testing.T) {
+	g := NewWithT(t)
+	app := setupVerifyApp(fakeRevocationStore{})
+
+	token := signToken(t, validClaims(), jwt.SigningMethodHS256, testAccessSecret)
+	code, body := doVerify(t, app, "Bearer "+token, "")
+
+	g.Expect(code).To(Equal(fiber.StatusOK))
+	g.Expect(body).To(Equal("42:7"))
+}
+
+func TestVerify_Cookie(t *testing.T) {
+	g := NewWithT(t)
+	app := setupVerifyApp(fakeRevocationStore{})
+
+	token := signToken(t, validClaims(), jwt.SigningMethodHS256, testAccessSecret)
+	code, body := doVerify(t, app, "", token)
+
+	g.Expect(code).To(Equal(fiber.StatusOK))
+	g.Expect(body).To(Equal("42:7"))
+}
+
+func TestVerify_HeaderTakesPrecedenceOverCookie(t *testing.T) {
+	g := NewWithT(t)
+	app := setupVerifyApp(fakeRevocationStore{})
+
+	token := signToken(t, validClaims(), jwt.SigningMethodHS256, testAccessSecret)
+	code, body := doVerify(t, app, "Bearer not-a-jwt", token)
+
+	g.Expect(code).To(Equal(fiber.StatusBadRequest))
+	g.Expect(body).To(ContainSubstring("Token malformed"))
+}
+
+func TestVerify_ErrorContract(t *testing.T) {
+	valid := signToken(t, validClaims(), jwt.SigningMethodHS256, testAccessSecret)
+
+	expired := validClaims()
+	expired.IssuedAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	notYetValid := validClaims()
+	notYetValid.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	noExpiry := validClaims()
+	noExpiry.ExpiresAt = nil
+
+	noId := validClaims()
+	noId.ID = ""
+
+	badSubject := validClaims()
+	badSubject.Subject = "not-a-number"
+
+	cases := []struct {
+		name    string
+		header  string
+		cookie  string
+		store   fakeRevocationStore
+		code    int
+		message string
+	}{
+		{"missing token", "", "", fakeRevocationStore{}, fiber.StatusUnauthorized, "Missing token"},
+		{"non bearer scheme", "Basic dXNlcjpwYXNz", "", fakeRevocationStore{}, fiber.StatusBadRequest, "Malformed authorization header"},
+		{"bearer without token", "Bearer ", "", fakeRevocationStore{}, fiber.StatusBadRequest, "Malformed authorization header"},
+		{"token without scheme", valid, "", fakeRevocationStore{}, fiber.StatusBadRequest, "Malformed authorization header"},
+		{"malformed token", "Bearer abc.def", "", fakeRevocationStore{}, fiber.StatusBadRequest, "Token malformed"},
+		{"expired token", "Bearer " + signToken(t, expired, jwt.SigningMethodHS256, testAccessSecret), "", fakeRevocationStore{}, fiber.StatusUnauthorized, "Token expired"},
+		{"wrong secret", "Bearer " + signToken(t, validClaims(), jwt.SigningMethodHS256, "other-secret"), "", fakeRevocationStore{}, fiber.StatusUnauthorized, "invalid signature"},
+		{"wrong algorithm", "Bearer " + signToken(t, validClaims(), jwt.SigningMethodHS512, testAccessSecret), "", fakeRevocationStore{}, fiber.StatusUnauthorized, "invalid signature"},
+		{"not valid yet", "Bearer " + signToken(t, notYetValid, jwt.SigningMethodHS256, testAccessSecret), "", fakeRevocationStore{}, fiber.StatusUnauthorized, "Token not valid yet"},
+		{"missing expiry", "Bearer " + signToken(t, noExpiry, jwt.SigningMethodHS256, testAccessSecret), "", fakeRevocationStore{}, fiber.StatusUnauthorized, "invalid claims"},
+		{"missing jti", "Bearer " + signToken(t, noId, jwt.SigningMethodHS256, testAccessSecret), "", fakeRevocationStore{}, fiber.StatusUnauthorized, "invalid claims"},
+		{"invalid subject", "Bearer " + signToken(t, badSubject, jwt.SigningMethodHS256, testAccessSecret), "", fakeRevocationStore{}, fiber.StatusUnauthorized, "invalid claims"},
+		{"malformed cookie", "", "garbage", fakeRevocationStore{}, fiber.StatusBadRequest, "Token malformed"},
+		{"revoked token", "Bearer " + valid, "", fakeRevocationStore{revoked: true}, fiber.StatusUnauthorized, "Token revoked"},
+		{"revocation store failure", "Bearer " + valid, "", fakeRevocationStore{fail: true}, fiber.StatusInternalServerError, "Failed to verify token"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			app := setupVerifyApp(tc.store)
+
+			code, body := doVerify(t, app, tc.header, tc.cookie)
+
+			g.Expect(code).To(Equal(tc.code))
+			g.Expect(body).To(ContainSubstring(tc.message))
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	collector := validClaims()
+
+	seller := validClaims()
+	seller.Role = entity.SELLER
+	seller.ProfileID = "3"
+
+	company := validClaims()
+	company.Role = entity.COMPANY
+	company.ProfileID = "9"
+
+	noRole := validClaims()
+	noRole.Role = ""
+
+	cases := []struct {
+		name   string
+		path   string
+		claims auth.JWTClaims
+		code   int
+		body   string
+	}{
+		{"collector on collector route", "/collector", collector, fiber.StatusOK, "collector"},
+		{"seller on collector route", "/collector", seller, fiber.StatusForbidden, "permission"},
+		{"company on collector route", "/collector", company, fiber.StatusForbidden, "permission"},
+		{"missing role", "/collector", noRole, fiber.StatusForbidden, "Missing role"},
+		{"seller on partner route", "/partner", seller, fiber.StatusOK, "SELLER:3"},
+		{"company on partner route", "/partner", company, fiber.StatusOK, "COMPANY:9"},
+		{"collector on partner route", "/partner", collector, fiber.StatusForbidden, "permission"},
+		{"seller has no collector id", "/", seller, fiber.StatusOK, "42:<nil>"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			app := setupVerifyApp(fakeRevocationStore{})
+
+			token := signToken(t, tc.claims, jwt.SigningMethodHS256, testAccessSecret)
+			code, body := doRequest(t, app, tc.path, "Bearer "+token, "")
+
+			g.Expect(code).To(Equal(tc.code))
+			g.Expect(body).To(ContainSubstring(tc.body))
+		})
+	}
+}
+
+func TestVerify_LocalizedError(t *testing.T) {
+	cases := []struct {
+		name     string
+		language string
+		message  string
+	}{
+		{"indonesian", "id-ID,id;q=0.9,en;q=0.8", `"message":"Token tidak ditemukan"`},
+		{"english", "en-US", `"message":"Missing token"`},
+		{"unsupported falls back to english", "fr", `"message":"Missing token"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			app := setupVerifyApp(fakeRevocationStore{})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(fiber.HeaderAcceptLanguage, tc.language)
+
+			res, err := app.Test(req)
+			g.Expect(err).ToNot(HaveOccurred())
+			defer res.Body.Close()
+
+			body, _ := io.ReadAll(res.Body)
+			g.Expect(res.StatusCode).To(Equal(fiber.StatusUnauthorized))
+			g.Expect(string(body)).To(ContainSubstring(tc.message))
+			g.Expect(string(body)).To(ContainSubstring(`"error_code":"MISSING_TOKEN"`))
+		})
+	}
+}
+
+func TestRequireAdminKey(t *testing.T) {
+	const adminKey = "admin-key-for-tests-at-least-32-chars"
+
+	cases := []struct {
+		name       string
+		configured string
+		header     string
+		code       int
+	}{
+		{"valid key", adminKey, adminKey, fiber.StatusOK},
+		{"wrong key", adminKey, adminKey + "x", fiber.StatusUnauthorized},
+		{"missing header", adminKey, "", fiber.StatusUnauthorized},
+		{"key not configured", "", "", fiber.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mw := NewHTTPMiddleware(&config.Config{ADMIN_API_KEY: tc.configured}, nil, fakeRevocationStore{})
+
+			app := fiber.New()
+			app.Post("/", mw.RequireAdminKey, func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(config.ADMIN_KEY_HEADER_NAME, tc.header)
+			}
+
+			res, err := app.Test(req)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.StatusCode).To(Equal(tc.code))
+		})
+	}
+}
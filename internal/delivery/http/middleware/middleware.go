@@ -1,11 +1,16 @@
 package middleware
 
-import "github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+import (
+	"github.com/crazydw4rf/oil-bank-backend/internal/auth"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+)
 
 type HTTPMiddleware struct {
-	cfg *config.Config
+	cfg         *config.Config
+	keys        *auth.AccessTokenKeys
+	revocations auth.RevocationStore
 }
 
-func NewHTTPMiddleware(cfg *config.Config) HTTPMiddleware {
-	return HTTPMiddleware{cfg}
+func NewHTTPMiddleware(cfg *config.Config, keys *auth.AccessTokenKeys, revocations auth.RevocationStore) HTTPMiddleware {
+	return HTTPMiddleware{cfg, keys, revocations}
 }
@@ -1,16 +1,50 @@
 package response
 
 import (
-	"fmt"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 
+	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/validator"
 	"github.com/gofiber/fiber/v2"
 )
 
+// HTTPError.ErrorCode adalah kode yang stabil untuk dibaca mesin,
+// sedangkan Message diterjemahkan sesuai header Accept-Language.
 type HTTPError struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	IsExpected bool   `json:"is_expected"`
+	Code       int                    `json:"code"`
+	ErrorCode  string                 `json:"error_code"`
+	Message    string                 `json:"message"`
+	IsExpected bool                   `json:"is_expected"`
+	Fields     []validator.FieldError `json:"fields,omitempty"`
+	TraceId    string                 `json:"trace_id,omitempty"`
+
+	cause ErrorCause
+}
+
+// PROBLEM_JSON_CONTENT_TYPE dipakai jika client meminta format error RFC 7807 lewat header Accept
+const PROBLEM_JSON_CONTENT_TYPE = "application/problem+json"
+
+// PROBLEM_TYPE_PREFIX adalah awalan URI type pada problem details, diikuti kode ErrorCause
+const PROBLEM_TYPE_PREFIX = "urn:oil-bank:problem:"
+
+// ProblemDetails adalah bentuk error menurut RFC 7807.
+// Type dan Title diturunkan dari ErrorCause, sedangkan Detail dan ErrorCode sama dengan HTTPError.
+type ProblemDetails struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail"`
+	Instance  string                 `json:"instance,omitempty"`
+	ErrorCode string                 `json:"error_code"`
+	Errors    []validator.FieldError `json:"errors,omitempty"`
+	TraceId   string                 `json:"trace_id,omitempty"`
 }
 
 type HTTPResponse[T any] struct {
@@ -19,13 +53,22 @@ type HTTPResponse[T any] struct {
 }
 
 var errorMapping = map[ErrorCause]int{
-	CREDENTIALS_ERROR:      fiber.StatusUnauthorized,
-	ENTITY_NOT_FOUND:       fiber.StatusNotFound,
-	ENTITY_DUPLICATE:       fiber.StatusConflict,
-	TOKEN_GENERATION_ERROR: fiber.StatusForbidden,
-	INTERNAL_SERVICE_ERROR: fiber.StatusInternalServerError,
-	BAD_REQUEST_ERROR:      fiber.StatusBadRequest,
-	UNKNOWN_ERROR:          fiber.StatusInternalServerError,
+	CREDENTIALS_ERROR:          fiber.StatusUnauthorized,
+	FORBIDDEN_ERROR:            fiber.StatusForbidden,
+	ENTITY_NOT_FOUND:           fiber.StatusNotFound,
+	ENTITY_DUPLICATE:           fiber.StatusConflict,
+	TOKEN_GENERATION_ERROR:     fiber.StatusForbidden,
+	INTERNAL_SERVICE_ERROR:     fiber.StatusInternalServerError,
+	BAD_REQUEST_ERROR:          fiber.StatusBadRequest,
+	UNPROCESSABLE_ENTITY_ERROR: fiber.StatusUnprocessableEntity,
+	TOO_MANY_REQUESTS_ERROR:    fiber.StatusTooManyRequests,
+	UNKNOWN_ERROR:              fiber.StatusInternalServerError,
+}
+
+// statusCauses menentukan cause untuk status yang dipetakan oleh lebih dari satu ErrorCause
+var statusCauses = map[int]ErrorCause{
+	fiber.StatusForbidden:           FORBIDDEN_ERROR,
+	fiber.StatusInternalServerError: INTERNAL_SERVICE_ERROR,
 }
 
 func NewHTTPResponse[T any](ctx *fiber.Ctx, code int, data T) error {
@@ -36,38 +79,163 @@ func NewHTTPResponse[T any](ctx *fiber.Ctx, code int, data T) error {
 }
 
 func NewHTTPError(ctx *fiber.Ctx, err error) error {
-	httpErr := buildHTTPError(err)
-
-	fmt.Printf("Error: %#v\n", httpErr)
+	httpErr := buildHTTPError(err, language(ctx))
 
-	return ctx.Status(httpErr.Code).JSON(HTTPResponse[any]{
-		Data:  nil,
-		Error: httpErr,
-	})
+	return writeError(ctx, httpErr, err)
 }
 
+// NewHTTPErrorSimple menerjemahkan message jika nilainya adalah message id dari katalog i18n,
+// selain itu message dikirim apa adanya.
 func NewHTTPErrorSimple(ctx *fiber.Ctx, code int, message string, Expected ...bool) error {
 	var IsExpected bool = false
 	if len(Expected) > 0 {
 		IsExpected = Expected[0]
 	}
-	return ctx.Status(code).JSON(HTTPResponse[any]{
-		Data: nil,
-		Error: &HTTPError{
-			Code:       code,
-			Message:    message,
-			IsExpected: IsExpected,
-		},
-	})
+
+	cause := causeOfStatus(code)
+	errorCode := cause.Code()
+	if translated, ok := i18n.Translate(language(ctx), message); ok {
+		errorCode = message
+		message = translated
+	}
+
+	return writeError(ctx, &HTTPError{
+		Code:       code,
+		ErrorCode:  errorCode,
+		Message:    message,
+		IsExpected: IsExpected,
+		cause:      cause,
+	}, nil)
+}
+
+// ErrorHandler dipasang sebagai fiber.Config.ErrorHandler sehingga semua error yang dikembalikan handler,
+// termasuk panic yang ditangkap middleware recover, dikirim dengan format dan status yang sama.
+func ErrorHandler(ctx *fiber.Ctx, err error) error {
+	var fieldErrs validator.ValidationErrors
+	var fiberErr *fiber.Error
+
+	switch {
+	case errors.As(err, &fieldErrs):
+		return NewHTTPRequestError(ctx, fieldErrs)
+	case errors.As(err, &fiberErr):
+		return NewHTTPErrorSimple(ctx, fiberErr.Code, fiberErr.Message, fiberErr.Code < fiber.StatusInternalServerError)
+	default:
+		return NewHTTPError(ctx, err)
+	}
+}
+
+// Respond mengirim nilai result dengan status code. Jika result gagal errornya dikembalikan
+// lewat ResultError supaya dikirim oleh ErrorHandler.
+func Respond[T any](ctx *fiber.Ctx, code int, result Result[T], fallback string) error {
+	if result.IsError() {
+		return ResultError(result, fallback)
+	}
+
+	return NewHTTPResponse(ctx, code, result.Value())
+}
+
+// ResultError mengubah Result yang gagal menjadi error untuk ErrorHandler.
+// Error yang diharapkan dikirim apa adanya, selain itu client menerima 500 dengan pesan dari message id fallback
+// dan seluruh trace hanya ditulis ke log.
+func ResultError[T any](result Result[T], fallback string) error {
+	if err := result.ExpectedError(); err != nil {
+		return err
+	}
+
+	return NewError[T](result.Error()).WithMessage(fallback).LastError()
+}
+
+// NewHTTPRequestError mengirim error dari parsing dan validasi request.
+// Pelanggaran validasi dikirim sebagai 422 beserta daftar field yang salah, selain itu 400.
+func NewHTTPRequestError(ctx *fiber.Ctx, err error) error {
+	var fieldErrs validator.ValidationErrors
+	var errTrace *ErrorTrace
+	if !errors.As(err, &fieldErrs) {
+		if errors.As(err, &errTrace) {
+			return NewHTTPError(ctx, errTrace)
+		}
+		return NewHTTPErrorSimple(ctx, fiber.StatusBadRequest, err.Error(), true)
+	}
+
+	lang := language(ctx)
+	message, _ := i18n.Translate(lang, i18n.VALIDATION_FAILED)
+
+	return writeError(ctx, &HTTPError{
+		Code:       fiber.StatusUnprocessableEntity,
+		ErrorCode:  i18n.VALIDATION_FAILED,
+		Message:    message,
+		IsExpected: true,
+		Fields:     fieldErrs.Localize(lang),
+		cause:      UNPROCESSABLE_ENTITY_ERROR,
+	}, nil)
 }
 
-func buildHTTPError(err error) *HTTPError {
-	errTrace, ok := err.(*ErrorTrace)
+// writeError mengirim httpErr sebagai problem+json jika client memintanya, selain itu dalam HTTPResponse biasa.
+// Error dari server dan error yang membawa cause dicatat lewat logger milik request.
+func writeError(ctx *fiber.Ctx, httpErr *HTTPError, cause error) error {
+	httpErr.TraceId = traceId(ctx)
+
+	if cause != nil || httpErr.Code >= fiber.StatusInternalServerError {
+		level := slog.LevelWarn
+		if httpErr.Code >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		args := []any{"status", httpErr.Code, "error_code", httpErr.ErrorCode}
+		if cause != nil {
+			args = append(args, "error", cause)
+		}
+		logger.FromContext(ctx.UserContext()).Log(ctx.UserContext(), level, "request failed", args...)
+	}
+
+	if ctx.Accepts(fiber.MIMEApplicationJSON, PROBLEM_JSON_CONTENT_TYPE) != PROBLEM_JSON_CONTENT_TYPE {
+		return ctx.Status(httpErr.Code).JSON(HTTPResponse[any]{
+			Data:  nil,
+			Error: httpErr,
+		})
+	}
+
+	title, ok := i18n.Translate(language(ctx), httpErr.cause.Code())
 	if !ok {
+		title = httpErr.cause.String()
+	}
+
+	return ctx.Status(httpErr.Code).JSON(ProblemDetails{
+		Type:      PROBLEM_TYPE_PREFIX + strings.ToLower(strings.ReplaceAll(httpErr.cause.Code(), "_", "-")),
+		Title:     title,
+		Status:    httpErr.Code,
+		Detail:    httpErr.Message,
+		Instance:  ctx.OriginalURL(),
+		ErrorCode: httpErr.ErrorCode,
+		Errors:    httpErr.Fields,
+		TraceId:   httpErr.TraceId,
+	}, PROBLEM_JSON_CONTENT_TYPE)
+}
+
+// traceId mengembalikan request id yang diisi middleware requestid
+func traceId(ctx *fiber.Ctx) string {
+	id, _ := ctx.Locals(constants.RequestIdKey).(string)
+	return id
+}
+
+// language memilih bahasa dari header Accept-Language dan menuliskannya ke Content-Language
+func language(ctx *fiber.Ctx) i18n.Language {
+	lang := i18n.ParseAcceptLanguage(ctx.Get(fiber.HeaderAcceptLanguage))
+	ctx.Set(fiber.HeaderContentLanguage, string(lang))
+
+	return lang
+}
+
+func buildHTTPError(err error, lang i18n.Language) *HTTPError {
+	var errTrace *ErrorTrace
+	if !errors.As(err, &errTrace) {
+		message, _ := i18n.Translate(lang, i18n.INTERNAL_SERVICE_ERROR)
 		return &HTTPError{
 			Code:       fiber.StatusInternalServerError,
-			Message:    "Internal Server Error",
+			ErrorCode:  INTERNAL_SERVICE_ERROR.Code(),
+			Message:    message,
 			IsExpected: false,
+			cause:      INTERNAL_SERVICE_ERROR,
 		}
 	}
 
@@ -76,9 +244,46 @@ func buildHTTPError(err error) *HTTPError {
 		code = fiber.StatusInternalServerError
 	}
 
+	errorCode := errTrace.Cause().Code()
+	message := errTrace.Error()
+	if id := errTrace.MessageId(); id != "" {
+		if translated, ok := i18n.Translate(lang, id, errTrace.MessageArgs()...); ok {
+			errorCode = id
+			message = translated
+		}
+	} else if !errTrace.IsExpected {
+		// pesan error yang tidak diharapkan bisa berisi detail internal, jadi tidak dikirim ke client
+		message, _ = i18n.Translate(lang, errorCode)
+	}
+
 	return &HTTPError{
 		Code:       code,
-		Message:    errTrace.Error(),
+		ErrorCode:  errorCode,
+		Message:    message,
 		IsExpected: errTrace.IsExpected,
+		cause:      errTrace.Cause(),
+	}
+}
+
+// causeOfStatus mencari ErrorCause yang dipetakan ke status HTTP code
+func causeOfStatus(code int) ErrorCause {
+	if cause, ok := statusCauses[code]; ok {
+		return cause
+	}
+
+	for _, cause := range slices.Sorted(maps.Keys(errorMapping)) {
+		if errorMapping[cause] == code {
+			return cause
+		}
+	}
+
+	// status yang tidak punya cause sendiri, misalnya 405 atau 413, tetap dikelompokkan sebagai error client atau server
+	switch {
+	case code >= fiber.StatusInternalServerError:
+		return INTERNAL_SERVICE_ERROR
+	case code >= fiber.StatusBadRequest:
+		return BAD_REQUEST_ERROR
+	default:
+		return UNKNOWN_ERROR
 	}
 }
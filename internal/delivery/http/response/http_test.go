@@ -0,0 +1,233 @@
+package response
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/gofiber/fiber/v2/utils"
+	. "github.com/onsi/gomega"
+)
+
+type problemRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func setupErrorApp() *fiber.App {
+	app := fiber.New()
+	app.Use(requestid.New(requestid.Config{ContextKey: constants.RequestIdKey}))
+
+	app.Get("/not-found", func(c *fiber.Ctx) error {
+		err := NewError[bool]("user not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.USER_NOT_FOUND)
+		return NewHTTPError(c, err.ExpectedError())
+	})
+	app.Get("/invalid", func(c *fiber.Ctx) error {
+		return NewHTTPRequestError(c, validator.Validate(problemRequest{}))
+	})
+	app.Get("/simple", func(c *fiber.Ctx) error {
+		return NewHTTPErrorSimple(c, fiber.StatusUnauthorized, i18n.MISSING_TOKEN, true)
+	})
+
+	return app
+}
+
+func doErrorRequest(t *testing.T, path string, accept string) (*http.Response, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(fiber.HeaderXRequestID, "trace-123")
+	if accept != "" {
+		req.Header.Set(fiber.HeaderAccept, accept)
+	}
+
+	res, err := setupErrorApp().Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+	var body map[string]any
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("invalid json %q: %v", raw, err)
+	}
+
+	return res, body
+}
+
+func TestNewHTTPError_DefaultEnvelope(t *testing.T) {
+	g := NewWithT(t)
+
+	res, body := doErrorRequest(t, "/not-found", "")
+
+	g.Expect(res.StatusCode).To(Equal(fiber.StatusNotFound))
+	g.Expect(res.Header.Get(fiber.HeaderContentType)).To(HavePrefix(fiber.MIMEApplicationJSON))
+	g.Expect(body["error"]).To(HaveKeyWithValue("error_code", i18n.USER_NOT_FOUND))
+	g.Expect(body["error"]).To(HaveKeyWithValue("trace_id", "trace-123"))
+}
+
+func TestNewHTTPError_ProblemDetails(t *testing.T) {
+	g := NewWithT(t)
+
+	res, body := doErrorRequest(t, "/not-found", PROBLEM_JSON_CONTENT_TYPE)
+
+	g.Expect(res.StatusCode).To(Equal(fiber.StatusNotFound))
+	g.Expect(res.Header.Get(fiber.HeaderContentType)).To(Equal(PROBLEM_JSON_CONTENT_TYPE))
+	g.Expect(res.Header.Get(fiber.HeaderXRequestID)).To(Equal("trace-123"))
+	g.Expect(body).To(HaveKeyWithValue("type", PROBLEM_TYPE_PREFIX+"entity-not-found"))
+	g.Expect(body).To(HaveKeyWithValue("title", "Entity not found"))
+	g.Expect(body).To(HaveKeyWithValue("status", BeNumerically("==", fiber.StatusNotFound)))
+	g.Expect(body).To(HaveKeyWithValue("detail", "User not found"))
+	g.Expect(body).To(HaveKeyWithValue("instance", "/not-found"))
+	g.Expect(body).To(HaveKeyWithValue("error_code", i18n.USER_NOT_FOUND))
+	g.Expect(body).To(HaveKeyWithValue("trace_id", "trace-123"))
+	g.Expect(body).ToNot(HaveKey("data"))
+}
+
+func TestNewHTTPRequestError_ProblemDetailsFields(t *testing.T) {
+	g := NewWithT(t)
+
+	res, body := doErrorRequest(t, "/invalid", "application/problem+json, application/json;q=0.5")
+
+	g.Expect(res.StatusCode).To(Equal(fiber.StatusUnprocessableEntity))
+	g.Expect(body).To(HaveKeyWithValue("type", PROBLEM_TYPE_PREFIX+"unprocessable-entity-error"))
+	g.Expect(body).To(HaveKeyWithValue("error_code", i18n.VALIDATION_FAILED))
+	g.Expect(body["errors"]).To(ConsistOf(HaveKeyWithValue("field", "name")))
+}
+
+func TestNewHTTPErrorSimple_ProblemDetailsLocalized(t *testing.T) {
+	g := NewWithT(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/simple", nil)
+	req.Header.Set(fiber.HeaderAccept, PROBLEM_JSON_CONTENT_TYPE)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "id")
+
+	res, err := setupErrorApp().Test(req)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer res.Body.Close()
+
+	var body ProblemDetails
+	g.Expect(json.NewDecoder(res.Body).Decode(&body)).To(Succeed())
+	g.Expect(body.Status).To(Equal(fiber.StatusUnauthorized))
+	g.Expect(body.Type).To(Equal(PROBLEM_TYPE_PREFIX + "credentials-error"))
+	g.Expect(body.Title).To(Equal("Kredensial tidak valid"))
+	g.Expect(body.Detail).To(Equal("Token tidak ditemukan"))
+	g.Expect(body.ErrorCode).To(Equal(i18n.MISSING_TOKEN))
+	g.Expect(body.TraceId).ToNot(BeEmpty())
+}
+
+func setupErrorHandlerApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(recover.New())
+
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("Not implemented")
+	})
+	app.Get("/unexpected", func(c *fiber.Ctx) error {
+		result := NewError[int]("pq: connection refused")
+		return Respond(c, fiber.StatusOK, result, i18n.USER_GET_FAILED)
+	})
+	app.Get("/expected", func(c *fiber.Ctx) error {
+		result := Err(NewError[int]("user not found", true).WithCause(ENTITY_NOT_FOUND), "lookup failed")
+		return Respond(c, fiber.StatusOK, result, i18n.USER_GET_FAILED)
+	})
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return Respond(c, fiber.StatusOK, Ok(7), i18n.USER_GET_FAILED)
+	})
+	app.Get("/invalid", func(c *fiber.Ctx) error {
+		return validator.Validate(problemRequest{})
+	})
+
+	return app
+}
+
+func TestErrorHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		path      string
+		code      int
+		errorCode string
+		message   string
+	}{
+		{"panic", "/panic", fiber.StatusInternalServerError, INTERNAL_SERVICE_ERROR.Code(), "Internal service error"},
+		{"unexpected result error", "/unexpected", fiber.StatusInternalServerError, i18n.USER_GET_FAILED, "Failed to get user"},
+		{"expected result error", "/expected", fiber.StatusNotFound, ENTITY_NOT_FOUND.Code(), "user not found"},
+		{"validation error", "/invalid", fiber.StatusUnprocessableEntity, i18n.VALIDATION_FAILED, "Validation failed"},
+		{"unknown route", "/missing", fiber.StatusNotFound, ENTITY_NOT_FOUND.Code(), "Cannot GET /missing"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			res, err := setupErrorHandlerApp().Test(httptest.NewRequest(http.MethodGet, tc.path, nil))
+			g.Expect(err).ToNot(HaveOccurred())
+			defer res.Body.Close()
+
+			var body HTTPResponse[any]
+			g.Expect(json.NewDecoder(res.Body).Decode(&body)).To(Succeed())
+			g.Expect(res.StatusCode).To(Equal(tc.code))
+			g.Expect(body.Error).ToNot(BeNil())
+			g.Expect(body.Error.Code).To(Equal(tc.code))
+			g.Expect(body.Error.ErrorCode).To(Equal(tc.errorCode))
+			g.Expect(body.Error.Message).To(Equal(tc.message))
+		})
+	}
+}
+
+func TestErrorHandler_StatusWithoutCause(t *testing.T) {
+	cases := []struct {
+		code      int
+		errorCode string
+	}{
+		{fiber.StatusMethodNotAllowed, BAD_REQUEST_ERROR.Code()},
+		{fiber.StatusRequestTimeout, BAD_REQUEST_ERROR.Code()},
+		{fiber.StatusRequestEntityTooLarge, BAD_REQUEST_ERROR.Code()},
+		{fiber.StatusBadGateway, INTERNAL_SERVICE_ERROR.Code()},
+		{fiber.StatusServiceUnavailable, INTERNAL_SERVICE_ERROR.Code()},
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/status/:code", func(c *fiber.Ctx) error {
+		code, _ := c.ParamsInt("code")
+		return fiber.NewError(code)
+	})
+
+	for _, tc := range cases {
+		t.Run(strconv.Itoa(tc.code), func(t *testing.T) {
+			g := NewWithT(t)
+
+			res, err := app.Test(httptest.NewRequest(http.MethodGet, "/status/"+strconv.Itoa(tc.code), nil))
+			g.Expect(err).ToNot(HaveOccurred())
+			defer res.Body.Close()
+
+			var body HTTPResponse[any]
+			g.Expect(json.NewDecoder(res.Body).Decode(&body)).To(Succeed())
+			g.Expect(res.StatusCode).To(Equal(tc.code))
+			g.Expect(body.Error.Code).To(Equal(tc.code))
+			g.Expect(body.Error.ErrorCode).To(Equal(tc.errorCode))
+			g.Expect(body.Error.Message).To(Equal(utils.StatusMessage(tc.code)))
+		})
+	}
+}
+
+func TestRespond_Ok(t *testing.T) {
+	g := NewWithT(t)
+
+	res, err := setupErrorHandlerApp().Test(httptest.NewRequest(http.MethodGet, "/ok", nil))
+	g.Expect(err).ToNot(HaveOccurred())
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	g.Expect(res.StatusCode).To(Equal(fiber.StatusOK))
+	g.Expect(string(body)).To(Equal(`{"data":7}`))
+}
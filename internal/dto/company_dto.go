@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+)
+
+type AggregatePeriod string
+
+const (
+	PERIOD_DAY   AggregatePeriod = "day"
+	PERIOD_WEEK  AggregatePeriod = "week"
+	PERIOD_MONTH AggregatePeriod = "month"
+	PERIOD_YEAR  AggregatePeriod = "year"
+)
+
+func (p AggregatePeriod) IsValid() bool {
+	switch p {
+	case PERIOD_DAY, PERIOD_WEEK, PERIOD_MONTH, PERIOD_YEAR:
+		return true
+	}
+
+	return false
+}
+
+type CompanyProfileResponse struct {
+	CompanyId   int64           `json:"company_id"`
+	CompanyName string          `json:"company_name"`
+	UserId      int64           `json:"user_id"`
+	Username    string          `json:"username"`
+	Email       string          `json:"email"`
+	UserType    entity.UserType `json:"user_type"`
+}
+
+type CompanyDeliveryRequest struct {
+	PageRequest
+	CollectorId int64 `query:"collector_id"`
+}
+
+// StartDate dan EndDate memakai format YYYY-MM-DD, EndDate bersifat inklusif
+type CompanySummaryRequest struct {
+	Period    AggregatePeriod `query:"period"`
+	StartDate string          `query:"start_date"`
+	EndDate   string          `query:"end_date"`
+}
+
+type CompanySummaryResponse struct {
+	Period            AggregatePeriod          `json:"period"`
+	StartDate         time.Time                `json:"start_date"`
+	EndDate           time.Time                `json:"end_date"`
+	TotalTransactions int64                    `json:"total_transactions"`
+	TotalVolume       float64                  `json:"total_volume"`
+	TotalSpend        float64                  `json:"total_spend"`
+	Periods           []entity.PeriodAggregate `json:"periods"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+type HealthStatus string
+
+const (
+	HEALTH_UP   HealthStatus = "UP"
+	HEALTH_DOWN HealthStatus = "DOWN"
+)
+
+type BuildInfo struct {
+	Version string `json:"version"`
+	Env     string `json:"env"`
+}
+
+// HealthCheck adalah hasil satu pemeriksaan pada /readyz, Error hanya diisi jika Status DOWN
+type HealthCheck struct {
+	Status  HealthStatus `json:"status"`
+	Latency string       `json:"latency,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Details any          `json:"details,omitempty"`
+}
+
+type MigrationDetails struct {
+	Current  uint `json:"current"`
+	Expected uint `json:"expected"`
+	Dirty    bool `json:"dirty"`
+}
+
+type HealthResponse struct {
+	Status HealthStatus           `json:"status"`
+	Build  BuildInfo              `json:"build"`
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
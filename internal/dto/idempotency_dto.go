@@ -0,0 +1,8 @@
+package dto
+
+// IdempotentResult membungkus hasil request yang memakai Idempotency-Key.
+// Replayed bernilai true jika Value diambil dari respons request sebelumnya.
+type IdempotentResult[T any] struct {
+	Value    T
+	Replayed bool
+}
@@ -8,3 +8,7 @@ type OilResponse struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
+
+type OilUpdateRequest struct {
+	TotalVolume *float64 `json:"total_volume" validate:"required,gte=0"`
+}
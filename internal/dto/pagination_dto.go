@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+const (
+	DEFAULT_PAGE_LIMIT = 20
+	MAX_PAGE_LIMIT     = 100
+)
+
+type PageRequest struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+// Normalize mengisi nilai default dan membatasi limit supaya query tetap ringan
+func (p *PageRequest) Normalize() {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.Limit < 1 {
+		p.Limit = DEFAULT_PAGE_LIMIT
+	}
+	if p.Limit > MAX_PAGE_LIMIT {
+		p.Limit = MAX_PAGE_LIMIT
+	}
+}
+
+func (p PageRequest) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+type PageResponse[T any] struct {
+	Items      []T   `json:"items"`
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalItems int64 `json:"total_items"`
+	TotalPages int64 `json:"total_pages"`
+}
+
+func NewPageResponse[T any](items []T, page PageRequest, total int64) *PageResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	totalPages := total / int64(page.Limit)
+	if total%int64(page.Limit) != 0 {
+		totalPages++
+	}
+
+	return &PageResponse[T]{
+		Items:      items,
+		Page:       page.Page,
+		Limit:      page.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	}
+}
+
+// CursorPageResponse dipakai untuk keyset pagination, NextCursor kosong berarti halaman terakhir
+type CursorPageResponse[T any] struct {
+	Items      []T    `json:"items"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// EncodeCursor mengubah cursor menjadi string opaque yang aman dipakai di query string
+func EncodeCursor(cursor any) (string, error) {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func DecodeCursor(s string, cursor any) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, cursor)
+}
@@ -10,11 +10,11 @@ const (
 )
 
 type ReportByDate struct {
-	StartDate  time.Time  `json:"start_date"`
-	EndDate    time.Time  `json:"end_date"`
-	ReportType ReportType `json:"report_type"`
+	StartDate  time.Time  `json:"start_date" validate:"required"`
+	EndDate    time.Time  `json:"end_date" validate:"required,gtefield=StartDate"`
+	ReportType ReportType `json:"report_type" validate:"required,oneof=SALES PURCHASE"`
 }
 
 type ReportAll struct {
-	ReportType ReportType `json:"report_type"`
+	ReportType ReportType `json:"report_type" validate:"required,oneof=SALES PURCHASE"`
 }
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+)
+
+type SellerProfileResponse struct {
+	SellerId   int64           `json:"seller_id"`
+	SellerName string          `json:"seller_name"`
+	UserId     int64           `json:"user_id"`
+	Username   string          `json:"username"`
+	Email      string          `json:"email"`
+	UserType   entity.UserType `json:"user_type"`
+}
+
+type SellerMonthlyRequest struct {
+	Year int `query:"year"`
+}
@@ -2,6 +2,8 @@ package dto
 
 import (
 	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
 )
 
 type TransactionType string
@@ -12,18 +14,27 @@ const (
 )
 
 type TransactionCreateDto struct {
-	Email           string          `json:"email"`
-	OilVolume       float64         `json:"oil_volume"`
-	Price           float64         `json:"price"`
-	TransactionType TransactionType `json:"transaction_type"`
+	Email           string          `json:"email" validate:"required,email"`
+	OilVolume       float64         `json:"oil_volume" validate:"required,gt=0"`
+	Price           float64         `json:"price" validate:"required,gt=0"`
+	TransactionType TransactionType `json:"transaction_type" validate:"required,oneof=SELL BUY"`
 }
 
 type UpdateTransactionDto struct {
-	TransactionType TransactionType `json:"transaction_type" validate:"required"`
+	TransactionType TransactionType `json:"transaction_type" validate:"required,oneof=SELL BUY"`
 	OilVolume       float64         `json:"oil_volume" validate:"required,gt=0"`
 	Price           float64         `json:"price" validate:"required,gt=0"`
 }
 
+// MAX_CANCEL_REASON_LENGTH membatasi panjang alasan pembatalan yang disimpan untuk audit,
+// nilainya harus sama dengan tag validate pada CancelTransactionDto.Reason
+const MAX_CANCEL_REASON_LENGTH = 500
+
+type CancelTransactionDto struct {
+	TransactionType TransactionType `json:"transaction_type" validate:"required,oneof=SELL BUY"`
+	Reason          string          `json:"reason" validate:"notblank,max=500"`
+}
+
 type TransactionResponse struct {
 	Id              int64           `json:"id"`
 	SellerId        int64           `json:"seller_id,omitempty"`
@@ -31,12 +42,84 @@ type TransactionResponse struct {
 	OilVolume       float64         `json:"oil_volume"`
 	Price           float64         `json:"price"`
 	TransactionType TransactionType `json:"transaction_type"`
+	CancelledAt     *time.Time      `json:"cancelled_at,omitempty"`
+	CancelReason    *string         `json:"cancel_reason,omitempty"`
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
 }
 
+type SortOrder string
+
+const (
+	SORT_ASC  SortOrder = "asc"
+	SORT_DESC SortOrder = "desc"
+)
+
+// TransactionListRequest berisi query parameter GET /transactions.
+// Type kosong berarti SELL dan BUY, StartDate dan EndDate memakai format YYYY-MM-DD (inklusif).
+type TransactionListRequest struct {
+	Type           TransactionType             `query:"type" validate:"omitempty,oneof=SELL BUY"`
+	Email          string                      `query:"email"`
+	CounterpartyId int64                       `query:"counterparty_id" validate:"gte=0"`
+	StartDate      string                      `query:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate        string                      `query:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	MinVolume      float64                     `query:"min_volume" validate:"gte=0"`
+	MaxVolume      float64                     `query:"max_volume" validate:"omitempty,gte=0,gtefield=MinVolume"`
+	MinPrice       float64                     `query:"min_price" validate:"gte=0"`
+	MaxPrice       float64                     `query:"max_price" validate:"omitempty,gte=0,gtefield=MinPrice"`
+	Sort           entity.TransactionSortField `query:"sort" validate:"omitempty,oneof=created_at volume price total_amount"`
+	Order          SortOrder                   `query:"order" validate:"omitempty,oneof=asc desc"`
+	Cursor         string                      `query:"cursor"`
+	Limit          int                         `query:"limit"`
+}
+
 // - TextField email
 // - TextField volume minyak (liter)
 // - TextField Harga
 // - ComboBox (Jual, Beli)
 // - Button (tergantung pilihan combo box Jual/Beli)
+
+const (
+	// harus sama dengan tag validate pada SyncTransactionRequest.Items
+	MAX_SYNC_BATCH_SIZE = 100
+	// toleransi perbedaan jam perangkat collector terhadap server
+	SYNC_CLOCK_SKEW = 5 * time.Minute
+)
+
+type SyncItemStatus string
+
+const (
+	SYNC_CREATED   SyncItemStatus = "created"
+	SYNC_DUPLICATE SyncItemStatus = "duplicate"
+	SYNC_REJECTED  SyncItemStatus = "rejected"
+)
+
+// SyncTransactionItem adalah transaksi yang dicatat perangkat collector saat offline.
+// ClientId berupa UUID yang dibuat perangkat, ClientTimestamp adalah waktu transaksi terjadi.
+type SyncTransactionItem struct {
+	TransactionCreateDto
+	ClientId        string    `json:"client_id" validate:"required,uuid"`
+	ClientTimestamp time.Time `json:"client_timestamp" validate:"required"`
+}
+
+// item tidak divalidasi di sini karena item yang tidak valid ditolak satu per satu
+type SyncTransactionRequest struct {
+	Items []SyncTransactionItem `json:"items" validate:"required,min=1,max=100"`
+}
+
+type SyncItemResult struct {
+	ClientId        string               `json:"client_id"`
+	Status          SyncItemStatus       `json:"status"`
+	TransactionId   int64                `json:"transaction_id,omitempty"`
+	TransactionType TransactionType      `json:"transaction_type,omitempty"`
+	Transaction     *TransactionResponse `json:"transaction,omitempty"`
+	ReasonCode      string               `json:"reason_code,omitempty"`
+	Reason          string               `json:"reason,omitempty"`
+}
+
+type SyncTransactionResponse struct {
+	Created   int              `json:"created"`
+	Duplicate int              `json:"duplicate"`
+	Rejected  int              `json:"rejected"`
+	Items     []SyncItemResult `json:"items"`
+}
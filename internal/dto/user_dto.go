@@ -1,22 +1,38 @@
 package dto
 
-import "github.com/crazydw4rf/oil-bank-backend/internal/entity"
+import (
+	"time"
 
-// TODO: tambahkan validator nanti
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+)
 
 type UserCreateRequest struct {
-	Username string          `json:"username"`
-	Email    string          `json:"email"`
-	Password string          `json:"password"`
-	UserType entity.UserType `json:"user_type"`
+	Username string          `json:"username" validate:"required,min=7,max=50"`
+	Email    string          `json:"email" validate:"required,email"`
+	Password string          `json:"password" validate:"required,min=8,max=72"`
+	UserType entity.UserType `json:"user_type" validate:"required,oneof=SELLER COLLECTOR COMPANY"`
 }
 
 type UserLoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// UserUnlockRequest menghapus lockout login untuk email, IP, atau keduanya
+type UserUnlockRequest struct {
+	Email string `json:"email" validate:"required_without=IP,omitempty,email"`
+	IP    string `json:"ip" validate:"required_without=Email,omitempty,ip"`
 }
 
 type UserUpdateRequest struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 }
+
+type UserSession struct {
+	UserId         int64
+	TokenId        string
+	ExpiresAt      time.Time
+	FamilyId       string
+	RefreshTokenId string
+}
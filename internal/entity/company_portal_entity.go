@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type CompanyDelivery struct {
+	Id            int64      `db:"id" json:"id"`
+	CollectorId   int64      `db:"collector_id" json:"collector_id"`
+	CollectorName string     `db:"collector_name" json:"collector_name"`
+	Volume        float64    `db:"volume" json:"volume"`
+	Price         float64    `db:"price" json:"price"`
+	TotalAmount   float64    `db:"total_amount" json:"total_amount"`
+	ReceivedAt    *time.Time `db:"received_at" json:"received_at"`
+	CancelledAt   *time.Time `db:"cancelled_at" json:"cancelled_at"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+}
+
+func (d *CompanyDelivery) IsReceived() bool {
+	return d.ReceivedAt != nil
+}
+
+func (d *CompanyDelivery) IsCancelled() bool {
+	return d.CancelledAt != nil
+}
+
+type PeriodAggregate struct {
+	Period            time.Time `db:"period" json:"period"`
+	TotalTransactions int64     `db:"total_transactions" json:"total_transactions"`
+	TotalVolume       float64   `db:"total_volume" json:"total_volume"`
+	TotalAmount       float64   `db:"total_amount" json:"total_amount"`
+}
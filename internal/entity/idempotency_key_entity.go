@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type IdempotencyKey struct {
+	CollectorId    int64     `db:"collector_id" json:"collector_id"`
+	IdempotencyKey string    `db:"idempotency_key" json:"idempotency_key"`
+	RequestHash    string    `db:"request_hash" json:"request_hash"`
+	ResponseBody   []byte    `db:"response_body" json:"-"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt      time.Time `db:"expires_at" json:"expires_at"`
+}
+
+// IsCompleted bernilai false selama request pertama dengan key ini belum selesai
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.ResponseBody != nil
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type LoginAttempt struct {
+	AttemptKey  string     `db:"attempt_key" json:"attempt_key"`
+	Failures    int        `db:"failures" json:"failures"`
+	LockedUntil *time.Time `db:"locked_until" json:"locked_until"`
+	ExpiresAt   time.Time  `db:"expires_at" json:"expires_at"`
+}
+
+// RetryAfter mengembalikan sisa waktu lockout pada waktu now, 0 jika tidak sedang dikunci
+func (a *LoginAttempt) RetryAfter(now time.Time) time.Duration {
+	if a.LockedUntil == nil || !now.Before(*a.LockedUntil) {
+		return 0
+	}
+
+	return a.LockedUntil.Sub(now)
+}
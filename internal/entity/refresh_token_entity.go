@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+)
+
+type RefreshToken struct {
+	Id        int64      `db:"id" json:"id"`
+	UserId    int64      `db:"user_id" json:"user_id"`
+	TokenId   string     `db:"token_id" json:"token_id"`
+	FamilyId  string     `db:"family_id" json:"family_id"`
+	ExpiresAt time.Time  `db:"expires_at" json:"expires_at"`
+	UsedAt    *time.Time `db:"used_at" json:"used_at"`
+	RevokedAt *time.Time `db:"revoked_at" json:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+}
+
+func (t *RefreshToken) IsActive() bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && time.Now().Before(t.ExpiresAt)
+}
+
+func (t *RefreshToken) IsReused() bool {
+	return t.UsedAt != nil
+}
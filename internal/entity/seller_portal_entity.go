@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+type SellerTransactionHistory struct {
+	Id            int64      `db:"id" json:"id"`
+	CollectorId   int64      `db:"collector_id" json:"collector_id"`
+	CollectorName string     `db:"collector_name" json:"collector_name"`
+	Volume        float64    `db:"volume" json:"volume"`
+	Price         float64    `db:"price" json:"price"`
+	TotalAmount   float64    `db:"total_amount" json:"total_amount"`
+	CancelledAt   *time.Time `db:"cancelled_at" json:"cancelled_at"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+}
+
+type SellerSummary struct {
+	TotalTransactions  int64      `db:"total_transactions" json:"total_transactions"`
+	TotalVolume        float64    `db:"total_volume" json:"total_volume"`
+	TotalEarnings      float64    `db:"total_earnings" json:"total_earnings"`
+	FirstTransactionAt *time.Time `db:"first_transaction_at" json:"first_transaction_at"`
+	LastTransactionAt  *time.Time `db:"last_transaction_at" json:"last_transaction_at"`
+}
+
+type MonthlyAggregate struct {
+	Month             time.Time `db:"month" json:"month"`
+	TotalTransactions int64     `db:"total_transactions" json:"total_transactions"`
+	TotalVolume       float64   `db:"total_volume" json:"total_volume"`
+	TotalAmount       float64   `db:"total_amount" json:"total_amount"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// SyncedTransaction menghubungkan client_id dari perangkat collector dengan transaksi yang dibuat
+type SyncedTransaction struct {
+	CollectorId     int64     `db:"collector_id" json:"collector_id"`
+	ClientId        string    `db:"client_id" json:"client_id"`
+	TransactionType string    `db:"transaction_type" json:"transaction_type"`
+	TransactionId   *int64    `db:"transaction_id" json:"transaction_id"`
+	ClientCreatedAt time.Time `db:"client_created_at" json:"client_created_at"`
+	SyncedAt        time.Time `db:"synced_at" json:"synced_at"`
+}
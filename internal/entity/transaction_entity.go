@@ -5,13 +5,15 @@ import (
 )
 
 type SellTransaction struct {
-	Id          int64     `db:"id" json:"id"`
-	SellerId    int64     `db:"seller_id" json:"seller_id" validate:"required"`
-	CollectorId int64     `db:"collector_id" json:"collector_id" validate:"required"`
-	Volume      float64   `db:"volume" json:"volume" validate:"required,gt=0"`
-	Price       float64   `db:"price" json:"price" validate:"required,gt=0"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	Id           int64      `db:"id" json:"id"`
+	SellerId     int64      `db:"seller_id" json:"seller_id" validate:"required"`
+	CollectorId  int64      `db:"collector_id" json:"collector_id" validate:"required"`
+	Volume       float64    `db:"volume" json:"volume" validate:"required,gt=0"`
+	Price        float64    `db:"price" json:"price" validate:"required,gt=0"`
+	CancelledAt  *time.Time `db:"cancelled_at" json:"cancelled_at"`
+	CancelReason *string    `db:"cancel_reason" json:"cancel_reason"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
 }
 
 func (st *SellTransaction) IsValid() bool {
@@ -21,6 +23,10 @@ func (st *SellTransaction) IsValid() bool {
 		st.Price > 0
 }
 
+func (st *SellTransaction) IsCancelled() bool {
+	return st.CancelledAt != nil
+}
+
 func (st *SellTransaction) CalculateTotalAmount() float64 {
 	return st.Volume * st.Price
 }
@@ -30,13 +36,16 @@ func (st *SellTransaction) GetTotalAmount() float64 {
 }
 
 type DistributeTransaction struct {
-	Id          int64     `db:"id" json:"id"`
-	CollectorId int64     `db:"collector_id" json:"collector_id" validate:"required"`
-	CompanyId   int64     `db:"company_id" json:"company_id" validate:"required"`
-	Volume      float64   `db:"volume" json:"volume" validate:"required,gt=0"`
-	Price       float64   `db:"price" json:"price" validate:"required,gt=0"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	Id           int64      `db:"id" json:"id"`
+	CollectorId  int64      `db:"collector_id" json:"collector_id" validate:"required"`
+	CompanyId    int64      `db:"company_id" json:"company_id" validate:"required"`
+	Volume       float64    `db:"volume" json:"volume" validate:"required,gt=0"`
+	Price        float64    `db:"price" json:"price" validate:"required,gt=0"`
+	ReceivedAt   *time.Time `db:"received_at" json:"received_at"`
+	CancelledAt  *time.Time `db:"cancelled_at" json:"cancelled_at"`
+	CancelReason *string    `db:"cancel_reason" json:"cancel_reason"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
 }
 
 func (dt *DistributeTransaction) IsValid() bool {
@@ -46,6 +55,10 @@ func (dt *DistributeTransaction) IsValid() bool {
 		dt.Price > 0
 }
 
+func (dt *DistributeTransaction) IsCancelled() bool {
+	return dt.CancelledAt != nil
+}
+
 func (dt *DistributeTransaction) CalculateTotalAmount() float64 {
 	return dt.Volume * dt.Price
 }
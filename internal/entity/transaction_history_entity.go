@@ -0,0 +1,89 @@
+package entity
+
+import "time"
+
+// TransactionHistory adalah gabungan SellTransaction (SELL) dan DistributeTransaction (BUY)
+// dari sisi collector, counterparty berisi seller atau company lawan transaksinya
+type TransactionHistory struct {
+	Id                int64      `db:"id" json:"id"`
+	TransactionType   string     `db:"transaction_type" json:"transaction_type"`
+	CounterpartyId    int64      `db:"counterparty_id" json:"counterparty_id"`
+	CounterpartyName  string     `db:"counterparty_name" json:"counterparty_name"`
+	CounterpartyEmail string     `db:"counterparty_email" json:"counterparty_email"`
+	Volume            float64    `db:"volume" json:"volume"`
+	Price             float64    `db:"price" json:"price"`
+	TotalAmount       float64    `db:"total_amount" json:"total_amount"`
+	CancelledAt       *time.Time `db:"cancelled_at" json:"cancelled_at"`
+	CancelReason      *string    `db:"cancel_reason" json:"cancel_reason"`
+	CreatedAt         time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+type TransactionSortField string
+
+const (
+	SORT_BY_CREATED_AT   TransactionSortField = "created_at"
+	SORT_BY_VOLUME       TransactionSortField = "volume"
+	SORT_BY_PRICE        TransactionSortField = "price"
+	SORT_BY_TOTAL_AMOUNT TransactionSortField = "total_amount"
+)
+
+func (f TransactionSortField) IsValid() bool {
+	switch f {
+	case SORT_BY_CREATED_AT, SORT_BY_VOLUME, SORT_BY_PRICE, SORT_BY_TOTAL_AMOUNT:
+		return true
+	}
+
+	return false
+}
+
+// TransactionCursor menandai baris terakhir dari halaman sebelumnya.
+// Value hanya dipakai jika urutan bukan berdasarkan created_at.
+type TransactionCursor struct {
+	SortBy          TransactionSortField `json:"s"`
+	Descending      bool                 `json:"d"`
+	Value           float64              `json:"v,omitempty"`
+	CreatedAt       time.Time            `json:"c"`
+	Id              int64                `json:"i"`
+	TransactionType string               `json:"t"`
+}
+
+// CursorOf membuat cursor yang menunjuk ke transaksi tx dengan urutan yang sama
+func (c TransactionCursor) CursorOf(tx TransactionHistory) TransactionCursor {
+	next := TransactionCursor{
+		SortBy:          c.SortBy,
+		Descending:      c.Descending,
+		CreatedAt:       tx.CreatedAt,
+		Id:              tx.Id,
+		TransactionType: tx.TransactionType,
+	}
+
+	switch c.SortBy {
+	case SORT_BY_VOLUME:
+		next.Value = tx.Volume
+	case SORT_BY_PRICE:
+		next.Value = tx.Price
+	case SORT_BY_TOTAL_AMOUNT:
+		next.Value = tx.TotalAmount
+	}
+
+	return next
+}
+
+// TransactionFilter berisi filter listing transaksi, nilai kosong berarti tidak difilter
+type TransactionFilter struct {
+	CollectorId       int64
+	TransactionType   string
+	CounterpartyId    int64
+	CounterpartyEmail string
+	StartDate         *time.Time
+	EndDate           *time.Time // eksklusif
+	MinVolume         float64
+	MaxVolume         float64
+	MinPrice          float64
+	MaxPrice          float64
+	SortBy            TransactionSortField
+	Descending        bool
+	After             *TransactionCursor
+	Limit             int
+}
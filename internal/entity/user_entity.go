@@ -26,6 +26,15 @@ type User struct {
 	RefreshToken string      `db:"-" json:"-"`
 }
 
+func (t UserType) IsValid() bool {
+	switch t {
+	case SELLER, COLLECTOR, COMPANY:
+		return true
+	}
+
+	return false
+}
+
 type UserWithSeller struct {
 	User
 	SellerName string `json:"seller_name" db:"seller_name"`
@@ -44,6 +53,14 @@ type UserWithCompany struct {
 	CompanyId   int64  `json:"company_id" db:"company_id"`
 }
 
+// UserWithProfile berisi user beserta id dan nama profil sesuai user_type-nya
+// (Seller, Collector atau Company)
+type UserWithProfile struct {
+	User
+	ProfileName string `json:"profile_name" db:"profile_name"`
+	ProfileId   int64  `json:"profile_id" db:"profile_id"`
+}
+
 type UserAddress struct {
 	Id            int64     `db:"id" json:"id"`
 	StreetAddress string    `db:"street_address" json:"street_address"`
@@ -0,0 +1,91 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	EN Language = "en"
+	ID Language = "id"
+)
+
+// DEFAULT_LANGUAGE dipakai jika client tidak mengirim Accept-Language yang didukung
+const DEFAULT_LANGUAGE = EN
+
+func (l Language) IsSupported() bool {
+	switch l {
+	case EN, ID:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseAcceptLanguage memilih bahasa yang didukung dengan nilai q tertinggi dari header Accept-Language.
+// Hanya subtag utama yang dibandingkan, jadi id-ID dan en-US tetap dikenali.
+func ParseAcceptLanguage(header string) Language {
+	type weighted struct {
+		lang Language
+		q    float64
+	}
+
+	var candidates []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+
+		lang := Language(strings.ToLower(primary))
+		if !lang.IsSupported() {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, weighted{lang, q})
+	}
+
+	if len(candidates) == 0 {
+		return DEFAULT_LANGUAGE
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	return candidates[0].lang
+}
+
+// Translate mengembalikan pesan untuk id dalam bahasa lang, args dipakai untuk mengisi format pesan.
+// Jika id tidak ada di katalog hasil kedua bernilai false.
+func Translate(lang Language, id string, args ...any) (string, bool) {
+	messages, ok := catalog[id]
+	if !ok {
+		return "", false
+	}
+
+	format, ok := messages[lang]
+	if !ok {
+		format = messages[DEFAULT_LANGUAGE]
+	}
+
+	if len(args) == 0 {
+		return format, true
+	}
+
+	return fmt.Sprintf(format, args...), true
+}
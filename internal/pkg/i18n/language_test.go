@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := []struct {
+		header string
+		lang   Language
+	}{
+		{"", DEFAULT_LANGUAGE},
+		{"id", ID},
+		{"id-ID,id;q=0.9,en-US;q=0.8,en;q=0.7", ID},
+		{"en-US,en;q=0.9,id;q=0.8", EN},
+		{"fr-FR,fr;q=0.9,id;q=0.5", ID},
+		{"en;q=0.3, id;q=0.7", ID},
+		{"id;q=0, en", EN},
+		{"ja", DEFAULT_LANGUAGE},
+		{"*", DEFAULT_LANGUAGE},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.header, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ParseAcceptLanguage(tc.header)).To(Equal(tc.lang))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	g := NewWithT(t)
+
+	message, ok := Translate(ID, SELL_TRANSACTION_NOT_FOUND, 12)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(message).To(Equal("Transaksi penjualan dengan id 12 tidak ditemukan"))
+
+	message, ok = Translate(EN, VALIDATION_REQUIRED, "email")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(message).To(Equal("email is required"))
+
+	_, ok = Translate(EN, "Some raw message")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestCatalog_HasAllLanguages(t *testing.T) {
+	for id, messages := range catalog {
+		for _, lang := range []Language{EN, ID} {
+			if messages[lang] == "" {
+				t.Errorf("message %s has no %s translation", id, lang)
+			}
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package i18n
+
+// Message id dikirim ke client sebagai error_code, jadi nilainya tidak boleh diubah.
+// Id untuk setiap ErrorCause sama dengan nama cause tersebut.
+const (
+	ENTITY_DUPLICATE           = "ENTITY_DUPLICATE"
+	ENTITY_NOT_FOUND           = "ENTITY_NOT_FOUND"
+	INTERNAL_SERVICE_ERROR     = "INTERNAL_SERVICE_ERROR"
+	CREDENTIALS_ERROR          = "CREDENTIALS_ERROR"
+	FORBIDDEN_ERROR            = "FORBIDDEN_ERROR"
+	TOKEN_GENERATION_ERROR     = "TOKEN_GENERATION_ERROR"
+	TOKEN_EXPIRED_ERROR        = "TOKEN_EXPIRED_ERROR"
+	INTERNAL_LOGIC_ERROR       = "INTERNAL_LOGIC_ERROR"
+	BAD_REQUEST_ERROR          = "BAD_REQUEST_ERROR"
+	UNPROCESSABLE_ENTITY_ERROR = "UNPROCESSABLE_ENTITY_ERROR"
+	TOO_MANY_REQUESTS_ERROR    = "TOO_MANY_REQUESTS_ERROR"
+	UNKNOWN_ERROR              = "UNKNOWN_ERROR"
+
+	INVALID_REQUEST_BODY          = "INVALID_REQUEST_BODY"
+	INVALID_QUERY_PARAMETERS      = "INVALID_QUERY_PARAMETERS"
+	INVALID_PAGINATION_PARAMETERS = "INVALID_PAGINATION_PARAMETERS"
+	INVALID_YEAR                  = "INVALID_YEAR"
+	INVALID_USER_ID               = "INVALID_USER_ID"
+	INVALID_SELLER_ID             = "INVALID_SELLER_ID"
+	INVALID_COMPANY_ID            = "INVALID_COMPANY_ID"
+	INVALID_COLLECTOR_ID          = "INVALID_COLLECTOR_ID"
+	INVALID_OIL_ID                = "INVALID_OIL_ID"
+	INVALID_TRANSACTION_ID        = "INVALID_TRANSACTION_ID"
+	INVALID_DELIVERY_ID           = "INVALID_DELIVERY_ID"
+	INVALID_CLIENT_ID             = "INVALID_CLIENT_ID"
+	COLLECTOR_ID_NOT_FOUND        = "COLLECTOR_ID_NOT_FOUND"
+	REFERENCED_ENTITY_NOT_FOUND   = "REFERENCED_ENTITY_NOT_FOUND"
+	CONSTRAINT_VIOLATION          = "CONSTRAINT_VIOLATION"
+	SERVICE_SHUTTING_DOWN         = "SERVICE_SHUTTING_DOWN"
+
+	VALIDATION_FAILED     = "VALIDATION_FAILED"
+	VALIDATION_REQUIRED   = "VALIDATION_REQUIRED"
+	VALIDATION_EMAIL      = "VALIDATION_EMAIL"
+	VALIDATION_UUID       = "VALIDATION_UUID"
+	VALIDATION_ONEOF      = "VALIDATION_ONEOF"
+	VALIDATION_GT         = "VALIDATION_GT"
+	VALIDATION_GTE        = "VALIDATION_GTE"
+	VALIDATION_MIN_LENGTH = "VALIDATION_MIN_LENGTH"
+	VALIDATION_MIN_ITEMS  = "VALIDATION_MIN_ITEMS"
+	VALIDATION_MAX_LENGTH = "VALIDATION_MAX_LENGTH"
+	VALIDATION_MAX_ITEMS  = "VALIDATION_MAX_ITEMS"
+	VALIDATION_GTEFIELD   = "VALIDATION_GTEFIELD"
+	VALIDATION_DATE       = "VALIDATION_DATE"
+	VALIDATION_INVALID    = "VALIDATION_INVALID"
+
+	MISSING_TOKEN                  = "MISSING_TOKEN"
+	MALFORMED_AUTHORIZATION_HEADER = "MALFORMED_AUTHORIZATION_HEADER"
+	TOKEN_MALFORMED                = "TOKEN_MALFORMED"
+	TOKEN_EXPIRED                  = "TOKEN_EXPIRED"
+	TOKEN_INVALID_SIGNATURE        = "TOKEN_INVALID_SIGNATURE"
+	TOKEN_NOT_VALID_YET            = "TOKEN_NOT_VALID_YET"
+	TOKEN_INVALID_CLAIMS           = "TOKEN_INVALID_CLAIMS"
+	TOKEN_REVOKED                  = "TOKEN_REVOKED"
+	TOKEN_VERIFICATION_FAILED      = "TOKEN_VERIFICATION_FAILED"
+	TOKEN_NOT_FOUND                = "TOKEN_NOT_FOUND"
+	TOKEN_ALREADY_EXISTS           = "TOKEN_ALREADY_EXISTS"
+	MISSING_ROLE                   = "MISSING_ROLE"
+	PERMISSION_DENIED              = "PERMISSION_DENIED"
+	MISSING_REFRESH_TOKEN          = "MISSING_REFRESH_TOKEN"
+	REFRESH_TOKEN_EXPIRED          = "REFRESH_TOKEN_EXPIRED"
+	INVALID_REFRESH_TOKEN          = "INVALID_REFRESH_TOKEN"
+	REFRESH_TOKEN_REUSED           = "REFRESH_TOKEN_REUSED"
+	INVALID_SESSION                = "INVALID_SESSION"
+
+	PASSWORD_INVALID       = "PASSWORD_INVALID"
+	INVALID_CREDENTIALS    = "INVALID_CREDENTIALS"
+	USER_NOT_FOUND         = "USER_NOT_FOUND"
+	USER_PROFILE_NOT_FOUND = "USER_PROFILE_NOT_FOUND"
+	USER_ALREADY_EXISTS    = "USER_ALREADY_EXISTS"
+	USER_CREATE_FAILED     = "USER_CREATE_FAILED"
+	USER_GET_FAILED        = "USER_GET_FAILED"
+	LOGIN_FAILED           = "LOGIN_FAILED"
+	REFRESH_TOKEN_FAILED   = "REFRESH_TOKEN_FAILED"
+	LOGOUT_FAILED          = "LOGOUT_FAILED"
+	LOGOUT_ALL_FAILED      = "LOGOUT_ALL_FAILED"
+	LOGIN_LOCKED           = "LOGIN_LOCKED"
+	LOGIN_UNLOCK_FAILED    = "LOGIN_UNLOCK_FAILED"
+	INVALID_ADMIN_KEY      = "INVALID_ADMIN_KEY"
+
+	SELLER_NOT_FOUND              = "SELLER_NOT_FOUND"
+	SELLER_USER_NOT_FOUND         = "SELLER_USER_NOT_FOUND"
+	SELLER_PROFILE_FAILED         = "SELLER_PROFILE_FAILED"
+	SELLER_TRANSACTIONS_FAILED    = "SELLER_TRANSACTIONS_FAILED"
+	SELLER_SUMMARY_FAILED         = "SELLER_SUMMARY_FAILED"
+	SELLER_MONTHLY_SUMMARY_FAILED = "SELLER_MONTHLY_SUMMARY_FAILED"
+
+	COMPANY_NOT_FOUND             = "COMPANY_NOT_FOUND"
+	COMPANY_PROFILE_FAILED        = "COMPANY_PROFILE_FAILED"
+	COMPANY_DELIVERIES_FAILED     = "COMPANY_DELIVERIES_FAILED"
+	COMPANY_SUMMARY_FAILED        = "COMPANY_SUMMARY_FAILED"
+	INVALID_PERIOD                = "INVALID_PERIOD"
+	INVALID_START_DATE            = "INVALID_START_DATE"
+	INVALID_END_DATE              = "INVALID_END_DATE"
+	START_DATE_AFTER_END_DATE     = "START_DATE_AFTER_END_DATE"
+	DELIVERY_NOT_FOUND            = "DELIVERY_NOT_FOUND"
+	DELIVERY_CANCELLED            = "DELIVERY_CANCELLED"
+	DELIVERY_ALREADY_ACKNOWLEDGED = "DELIVERY_ALREADY_ACKNOWLEDGED"
+	DELIVERY_ACKNOWLEDGE_FAILED   = "DELIVERY_ACKNOWLEDGE_FAILED"
+	DELIVERY_ACKNOWLEDGE_CONFLICT = "DELIVERY_ACKNOWLEDGE_CONFLICT"
+
+	INVALID_REPORT_TYPE     = "INVALID_REPORT_TYPE"
+	REPORT_FAILED           = "REPORT_FAILED"
+	REPORT_ALL_FAILED       = "REPORT_ALL_FAILED"
+	SALES_REPORT_FAILED     = "SALES_REPORT_FAILED"
+	PURCHASES_REPORT_FAILED = "PURCHASES_REPORT_FAILED"
+
+	OIL_NOT_FOUND              = "OIL_NOT_FOUND"
+	OIL_ALREADY_EXISTS         = "OIL_ALREADY_EXISTS"
+	OIL_GET_FAILED             = "OIL_GET_FAILED"
+	OIL_INVENTORY_FAILED       = "OIL_INVENTORY_FAILED"
+	OIL_UPDATE_FAILED          = "OIL_UPDATE_FAILED"
+	OIL_DELETE_FAILED          = "OIL_DELETE_FAILED"
+	OIL_ADJUST_FAILED          = "OIL_ADJUST_FAILED"
+	OIL_VOLUME_NEGATIVE        = "OIL_VOLUME_NEGATIVE"
+	INSUFFICIENT_OIL_INVENTORY = "INSUFFICIENT_OIL_INVENTORY"
+
+	INVALID_TRANSACTION_TYPE              = "INVALID_TRANSACTION_TYPE"
+	TRANSACTION_NOT_FOUND                 = "TRANSACTION_NOT_FOUND"
+	SELL_TRANSACTION_NOT_FOUND            = "SELL_TRANSACTION_NOT_FOUND"
+	DISTRIBUTE_TRANSACTION_NOT_FOUND      = "DISTRIBUTE_TRANSACTION_NOT_FOUND"
+	TRANSACTION_UPDATE_FORBIDDEN          = "TRANSACTION_UPDATE_FORBIDDEN"
+	TRANSACTION_CANCEL_FORBIDDEN          = "TRANSACTION_CANCEL_FORBIDDEN"
+	TRANSACTION_CANCELLED                 = "TRANSACTION_CANCELLED"
+	TRANSACTION_ALREADY_CANCELLED         = "TRANSACTION_ALREADY_CANCELLED"
+	TRANSACTION_UPDATE_NEGATIVE_INVENTORY = "TRANSACTION_UPDATE_NEGATIVE_INVENTORY"
+	TRANSACTION_CANCEL_NEGATIVE_INVENTORY = "TRANSACTION_CANCEL_NEGATIVE_INVENTORY"
+	TRANSACTION_CREATE_FAILED             = "TRANSACTION_CREATE_FAILED"
+	TRANSACTION_UPDATE_FAILED             = "TRANSACTION_UPDATE_FAILED"
+	TRANSACTION_CANCEL_FAILED             = "TRANSACTION_CANCEL_FAILED"
+	TRANSACTION_LIST_FAILED               = "TRANSACTION_LIST_FAILED"
+	TRANSACTION_SYNC_FAILED               = "TRANSACTION_SYNC_FAILED"
+	INVALID_CURSOR                        = "INVALID_CURSOR"
+	CURSOR_SORT_MISMATCH                  = "CURSOR_SORT_MISMATCH"
+	IDEMPOTENCY_KEY_INVALID_LENGTH        = "IDEMPOTENCY_KEY_INVALID_LENGTH"
+	IDEMPOTENCY_KEY_MISMATCH              = "IDEMPOTENCY_KEY_MISMATCH"
+	IDEMPOTENCY_KEY_IN_PROGRESS           = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	IDEMPOTENCY_KEY_FAILED                = "IDEMPOTENCY_KEY_FAILED"
+	IDEMPOTENCY_KEY_NOT_FOUND             = "IDEMPOTENCY_KEY_NOT_FOUND"
+	CLIENT_TIMESTAMP_IN_FUTURE            = "CLIENT_TIMESTAMP_IN_FUTURE"
+	SYNCED_TRANSACTION_FAILED             = "SYNCED_TRANSACTION_FAILED"
+	SYNCED_TRANSACTION_NOT_FOUND          = "SYNCED_TRANSACTION_NOT_FOUND"
+)
+
+// catalog berisi format pesan untuk setiap message id.
+// Pesan validasi menerima nama field sebagai argumen pertama dan parameter aturan sebagai argumen kedua.
+var catalog = map[string]map[Language]string{
+	ENTITY_DUPLICATE:           {EN: "Entity already exists", ID: "Data sudah ada"},
+	ENTITY_NOT_FOUND:           {EN: "Entity not found", ID: "Data tidak ditemukan"},
+	INTERNAL_SERVICE_ERROR:     {EN: "Internal service error", ID: "Terjadi kesalahan pada server"},
+	CREDENTIALS_ERROR:          {EN: "Invalid credentials", ID: "Kredensial tidak valid"},
+	FORBIDDEN_ERROR:            {EN: "Forbidden", ID: "Akses ditolak"},
+	TOKEN_GENERATION_ERROR:     {EN: "Token generation error", ID: "Gagal membuat token"},
+	TOKEN_EXPIRED_ERROR:        {EN: "Token expired", ID: "Token sudah kedaluwarsa"},
+	INTERNAL_LOGIC_ERROR:       {EN: "Internal logic error", ID: "Terjadi kesalahan logika internal"},
+	BAD_REQUEST_ERROR:          {EN: "Bad request", ID: "Permintaan tidak valid"},
+	UNPROCESSABLE_ENTITY_ERROR: {EN: "Unprocessable entity", ID: "Data tidak dapat diproses"},
+	TOO_MANY_REQUESTS_ERROR:    {EN: "Too many requests", ID: "Terlalu banyak permintaan"},
+	UNKNOWN_ERROR:              {EN: "Unknown error", ID: "Terjadi kesalahan yang tidak diketahui"},
+
+	INVALID_REQUEST_BODY:          {EN: "Invalid request body", ID: "Body request tidak valid"},
+	INVALID_QUERY_PARAMETERS:      {EN: "Invalid query parameters", ID: "Parameter query tidak valid"},
+	INVALID_PAGINATION_PARAMETERS: {EN: "Invalid pagination parameters", ID: "Parameter paginasi tidak valid"},
+	INVALID_YEAR:                  {EN: "Invalid year", ID: "Tahun tidak valid"},
+	INVALID_USER_ID:               {EN: "Invalid user ID", ID: "ID user tidak valid"},
+	INVALID_SELLER_ID:             {EN: "Invalid seller ID", ID: "ID seller tidak valid"},
+	INVALID_COMPANY_ID:            {EN: "Invalid company ID", ID: "ID perusahaan tidak valid"},
+	INVALID_COLLECTOR_ID:          {EN: "Invalid collector ID", ID: "ID collector tidak valid"},
+	INVALID_OIL_ID:                {EN: "Invalid oil ID", ID: "ID data minyak tidak valid"},
+	INVALID_TRANSACTION_ID:        {EN: "Invalid transaction ID", ID: "ID transaksi tidak valid"},
+	INVALID_DELIVERY_ID:           {EN: "Invalid delivery ID", ID: "ID pengiriman tidak valid"},
+	INVALID_CLIENT_ID:             {EN: "Invalid client_id", ID: "client_id tidak valid"},
+	COLLECTOR_ID_NOT_FOUND:        {EN: "Collector ID not found", ID: "ID collector tidak ditemukan"},
+	REFERENCED_ENTITY_NOT_FOUND:   {EN: "Referenced entity not found", ID: "Data yang dirujuk tidak ditemukan"},
+	CONSTRAINT_VIOLATION:          {EN: "Constraint violation", ID: "Data melanggar batasan yang berlaku"},
+	SERVICE_SHUTTING_DOWN:         {EN: "Service is shutting down, please retry", ID: "Layanan sedang dimatikan, silakan coba lagi"},
+
+	VALIDATION_FAILED:     {EN: "Validation failed", ID: "Validasi gagal"},
+	VALIDATION_REQUIRED:   {EN: "%[1]s is required", ID: "%[1]s wajib diisi"},
+	VALIDATION_EMAIL:      {EN: "%[1]s must be a valid email address", ID: "%[1]s harus berupa alamat email yang valid"},
+	VALIDATION_UUID:       {EN: "%[1]s must be a valid UUID", ID: "%[1]s harus berupa UUID yang valid"},
+	VALIDATION_ONEOF:      {EN: "%[1]s must be one of %[2]s", ID: "%[1]s harus salah satu dari %[2]s"},
+	VALIDATION_GT:         {EN: "%[1]s must be greater than %[2]s", ID: "%[1]s harus lebih besar dari %[2]s"},
+	VALIDATION_GTE:        {EN: "%[1]s must be greater than or equal to %[2]s", ID: "%[1]s tidak boleh kurang dari %[2]s"},
+	VALIDATION_MIN_LENGTH: {EN: "%[1]s must be at least %[2]s characters", ID: "%[1]s minimal %[2]s karakter"},
+	VALIDATION_MIN_ITEMS:  {EN: "%[1]s must contain at least %[2]s items", ID: "%[1]s minimal berisi %[2]s item"},
+	VALIDATION_MAX_LENGTH: {EN: "%[1]s must not exceed %[2]s characters", ID: "%[1]s maksimal %[2]s karakter"},
+	VALIDATION_MAX_ITEMS:  {EN: "%[1]s must not contain more than %[2]s items", ID: "%[1]s maksimal berisi %[2]s item"},
+	VALIDATION_GTEFIELD:   {EN: "%[1]s must not be before %[2]s", ID: "%[1]s tidak boleh sebelum %[2]s"},
+	VALIDATION_DATE:       {EN: "%[1]s must be a date in YYYY-MM-DD format", ID: "%[1]s harus berupa tanggal dengan format YYYY-MM-DD"},
+	VALIDATION_INVALID:    {EN: "%[1]s is invalid", ID: "%[1]s tidak valid"},
+
+	MISSING_TOKEN:                  {EN: "Missing token", ID: "Token tidak ditemukan"},
+	MALFORMED_AUTHORIZATION_HEADER: {EN: "Malformed authorization header, expected 'Bearer <token>'", ID: "Header authorization tidak valid, gunakan format 'Bearer <token>'"},
+	TOKEN_MALFORMED:                {EN: "Token malformed", ID: "Format token tidak valid"},
+	TOKEN_EXPIRED:                  {EN: "Token expired", ID: "Token sudah kedaluwarsa"},
+	TOKEN_INVALID_SIGNATURE:        {EN: "Token has invalid signature", ID: "Tanda tangan token tidak valid"},
+	TOKEN_NOT_VALID_YET:            {EN: "Token not valid yet", ID: "Token belum berlaku"},
+	TOKEN_INVALID_CLAIMS:           {EN: "Token has invalid claims", ID: "Klaim token tidak valid"},
+	TOKEN_REVOKED:                  {EN: "Token revoked", ID: "Token sudah dicabut"},
+	TOKEN_VERIFICATION_FAILED:      {EN: "Failed to verify token", ID: "Gagal memverifikasi token"},
+	TOKEN_NOT_FOUND:                {EN: "Token not found", ID: "Token tidak ditemukan"},
+	TOKEN_ALREADY_EXISTS:           {EN: "Token already exists", ID: "Token sudah ada"},
+	MISSING_ROLE:                   {EN: "Missing role", ID: "Role tidak ditemukan"},
+	PERMISSION_DENIED:              {EN: "You don't have permission to access this resource", ID: "Anda tidak memiliki akses ke resource ini"},
+	MISSING_REFRESH_TOKEN:          {EN: "Missing refresh token", ID: "Refresh token tidak ditemukan"},
+	REFRESH_TOKEN_EXPIRED:          {EN: "Refresh token expired", ID: "Refresh token sudah kedaluwarsa"},
+	INVALID_REFRESH_TOKEN:          {EN: "Invalid refresh token", ID: "Refresh token tidak valid"},
+	REFRESH_TOKEN_REUSED:           {EN: "Refresh token has already been used, please log in again", ID: "Refresh token sudah pernah dipakai, silakan login ulang"},
+	INVALID_SESSION:                {EN: "Invalid session", ID: "Sesi tidak valid"},
+
+	PASSWORD_INVALID:       {EN: "Wrong password", ID: "Password salah"},
+	INVALID_CREDENTIALS:    {EN: "Invalid email or password", ID: "Email atau password salah"},
+	USER_NOT_FOUND:         {EN: "User not found", ID: "User tidak ditemukan"},
+	USER_PROFILE_NOT_FOUND: {EN: "User profile not found", ID: "Profil user tidak ditemukan"},
+	USER_ALREADY_EXISTS:    {EN: "User already exists", ID: "User sudah terdaftar"},
+	USER_CREATE_FAILED:     {EN: "Failed to create user", ID: "Gagal membuat user baru"},
+	USER_GET_FAILED:        {EN: "Failed to get user", ID: "Gagal mengambil data user"},
+	LOGIN_FAILED:           {EN: "Failed to login user", ID: "Gagal login"},
+	REFRESH_TOKEN_FAILED:   {EN: "Failed to refresh token", ID: "Gagal memperbarui token"},
+	LOGOUT_FAILED:          {EN: "Failed to logout user", ID: "Gagal logout"},
+	LOGOUT_ALL_FAILED:      {EN: "Failed to logout from all devices", ID: "Gagal logout dari semua perangkat"},
+	LOGIN_LOCKED:           {EN: "Too many failed login attempts, try again in %[1]d seconds", ID: "Terlalu banyak percobaan login gagal, coba lagi dalam %[1]d detik"},
+	LOGIN_UNLOCK_FAILED:    {EN: "Failed to unlock login", ID: "Gagal membuka kunci login"},
+	INVALID_ADMIN_KEY:      {EN: "Invalid admin key", ID: "Admin key tidak valid"},
+
+	SELLER_NOT_FOUND:              {EN: "Seller not found", ID: "Seller tidak ditemukan"},
+	SELLER_USER_NOT_FOUND:         {EN: "User seller not found", ID: "User seller tidak ditemukan"},
+	SELLER_PROFILE_FAILED:         {EN: "Failed to get seller profile", ID: "Gagal mengambil profil seller"},
+	SELLER_TRANSACTIONS_FAILED:    {EN: "Failed to get seller transactions", ID: "Gagal mengambil transaksi seller"},
+	SELLER_SUMMARY_FAILED:         {EN: "Failed to get seller summary", ID: "Gagal mengambil ringkasan seller"},
+	SELLER_MONTHLY_SUMMARY_FAILED: {EN: "Failed to get seller monthly summary", ID: "Gagal mengambil ringkasan bulanan seller"},
+
+	COMPANY_NOT_FOUND:             {EN: "Company not found", ID: "Perusahaan tidak ditemukan"},
+	COMPANY_PROFILE_FAILED:        {EN: "Failed to get company profile", ID: "Gagal mengambil profil perusahaan"},
+	COMPANY_DELIVERIES_FAILED:     {EN: "Failed to get company deliveries", ID: "Gagal mengambil daftar pengiriman"},
+	COMPANY_SUMMARY_FAILED:        {EN: "Failed to get company summary", ID: "Gagal mengambil ringkasan perusahaan"},
+	INVALID_PERIOD:                {EN: "Invalid period, must be one of day, week, month, year", ID: "Periode tidak valid, harus salah satu dari day, week, month, year"},
+	INVALID_START_DATE:            {EN: "Invalid start_date, expected format YYYY-MM-DD", ID: "start_date tidak valid, gunakan format YYYY-MM-DD"},
+	INVALID_END_DATE:              {EN: "Invalid end_date, expected format YYYY-MM-DD", ID: "end_date tidak valid, gunakan format YYYY-MM-DD"},
+	START_DATE_AFTER_END_DATE:     {EN: "start_date must not be after end_date", ID: "start_date tidak boleh setelah end_date"},
+	DELIVERY_NOT_FOUND:            {EN: "Delivery not found", ID: "Pengiriman tidak ditemukan"},
+	DELIVERY_CANCELLED:            {EN: "Delivery has been cancelled", ID: "Pengiriman sudah dibatalkan"},
+	DELIVERY_ALREADY_ACKNOWLEDGED: {EN: "Delivery already acknowledged", ID: "Pengiriman sudah dikonfirmasi"},
+	DELIVERY_ACKNOWLEDGE_FAILED:   {EN: "Failed to acknowledge delivery", ID: "Gagal mengonfirmasi pengiriman"},
+	DELIVERY_ACKNOWLEDGE_CONFLICT: {EN: "Delivery already acknowledged or cancelled", ID: "Pengiriman sudah dikonfirmasi atau dibatalkan"},
+
+	INVALID_REPORT_TYPE:     {EN: "Invalid report type", ID: "Jenis laporan tidak valid"},
+	REPORT_FAILED:           {EN: "Failed to get report", ID: "Gagal mengambil laporan"},
+	REPORT_ALL_FAILED:       {EN: "Failed to get all reports", ID: "Gagal mengambil semua laporan"},
+	SALES_REPORT_FAILED:     {EN: "Failed to get sales report", ID: "Gagal mengambil laporan penjualan"},
+	PURCHASES_REPORT_FAILED: {EN: "Failed to get purchases report", ID: "Gagal mengambil laporan pembelian"},
+
+	OIL_NOT_FOUND:              {EN: "Oil record not found", ID: "Data minyak tidak ditemukan"},
+	OIL_ALREADY_EXISTS:         {EN: "Oil record already exists for this collector", ID: "Data minyak untuk collector ini sudah ada"},
+	OIL_GET_FAILED:             {EN: "Failed to get oil record", ID: "Gagal mengambil data minyak"},
+	OIL_INVENTORY_FAILED:       {EN: "Failed to get oil inventory", ID: "Gagal mengambil stok minyak"},
+	OIL_UPDATE_FAILED:          {EN: "Failed to update oil record", ID: "Gagal mengubah data minyak"},
+	OIL_DELETE_FAILED:          {EN: "Failed to delete oil record", ID: "Gagal menghapus data minyak"},
+	OIL_ADJUST_FAILED:          {EN: "Failed to adjust oil inventory", ID: "Gagal menyesuaikan stok minyak"},
+	OIL_VOLUME_NEGATIVE:        {EN: "Total volume cannot be negative", ID: "Total volume tidak boleh negatif"},
+	INSUFFICIENT_OIL_INVENTORY: {EN: "Insufficient oil inventory", ID: "Stok minyak tidak mencukupi"},
+
+	INVALID_TRANSACTION_TYPE:              {EN: "Invalid transaction type. Must be SELL or BUY", ID: "Jenis transaksi tidak valid, harus SELL atau BUY"},
+	TRANSACTION_NOT_FOUND:                 {EN: "Transaction not found", ID: "Transaksi tidak ditemukan"},
+	SELL_TRANSACTION_NOT_FOUND:            {EN: "Sell transaction with id %v not found", ID: "Transaksi penjualan dengan id %v tidak ditemukan"},
+	DISTRIBUTE_TRANSACTION_NOT_FOUND:      {EN: "Distribute transaction with id %v not found", ID: "Transaksi distribusi dengan id %v tidak ditemukan"},
+	TRANSACTION_UPDATE_FORBIDDEN:          {EN: "You are not authorized to update this transaction", ID: "Anda tidak berhak mengubah transaksi ini"},
+	TRANSACTION_CANCEL_FORBIDDEN:          {EN: "You are not authorized to cancel this transaction", ID: "Anda tidak berhak membatalkan transaksi ini"},
+	TRANSACTION_CANCELLED:                 {EN: "Cancelled transaction cannot be updated", ID: "Transaksi yang sudah dibatalkan tidak dapat diubah"},
+	TRANSACTION_ALREADY_CANCELLED:         {EN: "Transaction already cancelled", ID: "Transaksi sudah dibatalkan"},
+	TRANSACTION_UPDATE_NEGATIVE_INVENTORY: {EN: "Cannot update transaction, collector oil inventory would become negative", ID: "Transaksi tidak dapat diubah karena stok minyak collector akan menjadi negatif"},
+	TRANSACTION_CANCEL_NEGATIVE_INVENTORY: {EN: "Cannot cancel transaction, collector oil inventory would become negative", ID: "Transaksi tidak dapat dibatalkan karena stok minyak collector akan menjadi negatif"},
+	TRANSACTION_CREATE_FAILED:             {EN: "Failed to create transaction", ID: "Gagal membuat transaksi"},
+	TRANSACTION_UPDATE_FAILED:             {EN: "Failed to update transaction", ID: "Gagal mengubah transaksi"},
+	TRANSACTION_CANCEL_FAILED:             {EN: "Failed to cancel transaction", ID: "Gagal membatalkan transaksi"},
+	TRANSACTION_LIST_FAILED:               {EN: "Failed to list transactions", ID: "Gagal mengambil daftar transaksi"},
+	TRANSACTION_SYNC_FAILED:               {EN: "Failed to sync transactions", ID: "Gagal menyinkronkan transaksi"},
+	INVALID_CURSOR:                        {EN: "Invalid cursor", ID: "Cursor tidak valid"},
+	CURSOR_SORT_MISMATCH:                  {EN: "Cursor does not match the requested sort", ID: "Cursor tidak sesuai dengan urutan yang diminta"},
+	IDEMPOTENCY_KEY_INVALID_LENGTH:        {EN: "Idempotency key must be between 1 and %v characters", ID: "Idempotency key harus terdiri dari 1 sampai %v karakter"},
+	IDEMPOTENCY_KEY_MISMATCH:              {EN: "Idempotency key has already been used with a different request body", ID: "Idempotency key sudah dipakai untuk body request yang berbeda"},
+	IDEMPOTENCY_KEY_IN_PROGRESS:           {EN: "A request with this idempotency key is still being processed", ID: "Request dengan idempotency key ini masih diproses"},
+	IDEMPOTENCY_KEY_FAILED:                {EN: "Failed to process idempotency key", ID: "Gagal memproses idempotency key"},
+	IDEMPOTENCY_KEY_NOT_FOUND:             {EN: "Idempotency key not found", ID: "Idempotency key tidak ditemukan"},
+	CLIENT_TIMESTAMP_IN_FUTURE:            {EN: "client_timestamp must not be in the future", ID: "client_timestamp tidak boleh di masa depan"},
+	SYNCED_TRANSACTION_FAILED:             {EN: "Failed to record synced transaction", ID: "Gagal mencatat transaksi yang disinkronkan"},
+	SYNCED_TRANSACTION_NOT_FOUND:          {EN: "Synced transaction not found", ID: "Transaksi yang disinkronkan tidak ditemukan"},
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
+	"github.com/gofiber/fiber/v2"
+)
+
+type contextKey struct{}
+
+// WithContext menyimpan l ke ctx supaya usecase dan repository bisa memakai logger milik request
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext mengembalikan logger yang disimpan WithContext, atau slog.Default() jika tidak ada
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+
+	return slog.Default()
+}
+
+// With menambahkan args ke logger milik request c, misalnya user id setelah token diverifikasi
+func With(c *fiber.Ctx, args ...any) {
+	ctx := c.UserContext()
+	c.SetUserContext(WithContext(ctx, FromContext(ctx).With(args...)))
+}
+
+// Middleware memasang logger berisi request id ke UserContext lalu mencatat setiap request
+// setelah selesai. Harus dipasang setelah middleware requestid.
+func Middleware(base *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		requestId, _ := c.Locals(constants.RequestIdKey).(string)
+		c.SetUserContext(WithContext(c.Context(), base.With(slog.String("request_id", requestId))))
+
+		// error dikirim di sini supaya status yang dicatat sama dengan yang diterima client
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		FromContext(c.UserContext()).LogAttrs(c.UserContext(), level, "request",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+		)
+
+		return nil
+	}
+}
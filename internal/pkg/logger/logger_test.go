@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	. "github.com/onsi/gomega"
+)
+
+func setupLoggerApp(buf *bytes.Buffer) *fiber.App {
+	base := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	app := fiber.New()
+	app.Use(requestid.New(requestid.Config{ContextKey: constants.RequestIdKey}))
+	app.Use(Middleware(base))
+
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		With(c, "user_id", "42")
+		FromContext(c.UserContext()).Info("handled")
+		return c.SendString("ok")
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return errors.New("boom")
+	})
+
+	return app
+}
+
+func readLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		line := map[string]any{}
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("invalid log line %q: %v", raw, err)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestMiddleware_RequestCorrelation(t *testing.T) {
+	g := NewWithT(t)
+	buf := new(bytes.Buffer)
+
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-1")
+
+	res, err := setupLoggerApp(buf).Test(req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.StatusCode).To(Equal(fiber.StatusOK))
+
+	lines := readLines(t, buf)
+	g.Expect(lines).To(HaveLen(2))
+	g.Expect(lines[0]).To(HaveKeyWithValue("msg", "handled"))
+	g.Expect(lines[0]).To(HaveKeyWithValue("request_id", "req-1"))
+	g.Expect(lines[0]).To(HaveKeyWithValue("user_id", "42"))
+	g.Expect(lines[1]).To(HaveKeyWithValue("msg", "request"))
+	g.Expect(lines[1]).To(HaveKeyWithValue("level", "INFO"))
+	g.Expect(lines[1]).To(HaveKeyWithValue("user_id", "42"))
+	g.Expect(lines[1]).To(HaveKeyWithValue("status", BeNumerically("==", fiber.StatusOK)))
+}
+
+func TestMiddleware_ErrorStatus(t *testing.T) {
+	g := NewWithT(t)
+	buf := new(bytes.Buffer)
+
+	res, err := setupLoggerApp(buf).Test(httptest.NewRequest(http.MethodGet, "/fail", nil))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.StatusCode).To(Equal(fiber.StatusInternalServerError))
+
+	lines := readLines(t, buf)
+	g.Expect(lines).To(HaveLen(1))
+	g.Expect(lines[0]).To(HaveKeyWithValue("level", "ERROR"))
+	g.Expect(lines[0]).To(HaveKeyWithValue("status", BeNumerically("==", fiber.StatusInternalServerError)))
+	g.Expect(lines[0]).To(HaveKeyWithValue("request_id", Not(BeEmpty())))
+}
+
+func TestFromContext_Default(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(FromContext(nil)).To(Equal(slog.Default()))
+}
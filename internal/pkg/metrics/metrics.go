@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const NAMESPACE = "oil_bank"
+
+// UNMATCHED_ROUTE dipakai sebagai label route untuk request yang tidak cocok dengan route manapun,
+// supaya path acak dari client tidak membuat label baru
+const UNMATCHED_ROUTE = "unmatched"
+
+// Metrics menyimpan semua collector aplikasi dalam registry sendiri.
+// Collector tetap dihitung walaupun endpoint /metrics dimatikan lewat config.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	transactions          *prometheus.CounterVec
+	oilVolume             *prometheus.CounterVec
+	insufficientInventory prometheus.Counter
+	loginFailures         *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "http_requests_total",
+			Help:      "Total HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: NAMESPACE,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "transactions_created_total",
+			Help:      "Committed transactions by type (SELL or BUY).",
+		}, []string{"type"}),
+		oilVolume: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "oil_volume_litres_total",
+			Help:      "Litres of oil purchased from sellers and distributed to companies.",
+		}, []string{"direction"}),
+		insufficientInventory: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "distribution_insufficient_inventory_total",
+			Help:      "Distributions rejected because the collector inventory was insufficient.",
+		}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "login_failures_total",
+			Help:      "Failed logins by reason.",
+		}, []string{"reason"}),
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.transactions,
+		m.oilVolume,
+		m.insufficientInventory,
+		m.loginFailures,
+	)
+
+	return m
+}
+
+// Middleware mencatat jumlah dan lama request. Label route memakai pola route (misal /v1/transactions/:id),
+// bukan path asli, supaya jumlah label tetap kecil.
+func (m *Metrics) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		self := c.Route()
+
+		// error dikirim di sini supaya status yang dicatat sama dengan yang diterima client
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		// jika tidak ada route lain yang cocok, c.Route() masih menunjuk ke middleware ini
+		route := c.Route().Path
+		if c.Route() == self {
+			route = UNMATCHED_ROUTE
+		}
+
+		labels := prometheus.Labels{
+			"method": c.Method(),
+			"route":  route,
+			"status": strconv.Itoa(c.Response().StatusCode()),
+		}
+		m.httpRequests.With(labels).Inc()
+		m.httpDuration.With(labels).Observe(time.Since(start).Seconds())
+
+		return nil
+	}
+}
+
+// Handler mengirim isi registry dalam format Prometheus
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry}))
+}
+
+// TransactionCreated dipanggil setelah transaksi berhasil di-commit
+func (m *Metrics) TransactionCreated(transactionType dto.TransactionType, volume float64) {
+	m.transactions.WithLabelValues(string(transactionType)).Inc()
+
+	switch transactionType {
+	case dto.TRANSACTION_SELL:
+		m.oilVolume.WithLabelValues("purchased").Add(volume)
+	case dto.TRANSACTION_BUY:
+		m.oilVolume.WithLabelValues("distributed").Add(volume)
+	}
+}
+
+func (m *Metrics) InsufficientInventory() {
+	m.insufficientInventory.Inc()
+}
+
+func (m *Metrics) LoginFailed(reason string) {
+	m.loginFailures.WithLabelValues(reason).Inc()
+}
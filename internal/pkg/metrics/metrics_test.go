@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware_RouteLabels(t *testing.T) {
+	g := NewWithT(t)
+	m := New()
+
+	app := fiber.New()
+	app.Use(m.Middleware())
+	app.Get("/v1/oils/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.ErrBadRequest
+	})
+
+	for _, path := range []string{"/v1/oils/1", "/v1/oils/2", "/fail", "/random/path"} {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		g.Expect(err).ToNot(HaveOccurred())
+		res.Body.Close()
+	}
+
+	g.Expect(testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/v1/oils/:id", "200"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/fail", "400"))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", UNMATCHED_ROUTE, "404"))).To(Equal(1.0))
+}
+
+func TestTransactionCreated(t *testing.T) {
+	g := NewWithT(t)
+	m := New()
+
+	m.TransactionCreated(dto.TRANSACTION_SELL, 10)
+	m.TransactionCreated(dto.TRANSACTION_SELL, 2.5)
+	m.TransactionCreated(dto.TRANSACTION_BUY, 4)
+
+	g.Expect(testutil.ToFloat64(m.transactions.WithLabelValues(string(dto.TRANSACTION_SELL)))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(m.transactions.WithLabelValues(string(dto.TRANSACTION_BUY)))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(m.oilVolume.WithLabelValues("purchased"))).To(Equal(12.5))
+	g.Expect(testutil.ToFloat64(m.oilVolume.WithLabelValues("distributed"))).To(Equal(4.0))
+}
@@ -2,6 +2,7 @@ package result
 
 type ErrorCause uint8
 
+// Cause baru selalu ditambahkan di akhir supaya nilai cause yang sudah ada tidak berubah
 const (
 	ENTITY_DUPLICATE ErrorCause = iota + 1
 	ENTITY_NOT_FOUND
@@ -12,18 +13,48 @@ const (
 	INTERNAL_LOGIC_ERROR
 	BAD_REQUEST_ERROR
 	UNKNOWN_ERROR
+	FORBIDDEN_ERROR
+	UNPROCESSABLE_ENTITY_ERROR
+	TOO_MANY_REQUESTS_ERROR
 )
 
 var ErrorMessages = map[ErrorCause]string{
-	ENTITY_DUPLICATE:       "Entity already exists",
-	ENTITY_NOT_FOUND:       "Entity not found",
-	INTERNAL_SERVICE_ERROR: "Internal service error",
-	CREDENTIALS_ERROR:      "Invalid credentials",
-	TOKEN_GENERATION_ERROR: "Token generation error",
-	TOKEN_EXPIRED_ERROR:    "Token expired",
-	INTERNAL_LOGIC_ERROR:   "Internal logic error",
-	BAD_REQUEST_ERROR:      "Bad request",
-	UNKNOWN_ERROR:          "Unknown error",
+	ENTITY_DUPLICATE:           "Entity already exists",
+	ENTITY_NOT_FOUND:           "Entity not found",
+	INTERNAL_SERVICE_ERROR:     "Internal service error",
+	CREDENTIALS_ERROR:          "Invalid credentials",
+	TOKEN_GENERATION_ERROR:     "Token generation error",
+	TOKEN_EXPIRED_ERROR:        "Token expired",
+	INTERNAL_LOGIC_ERROR:       "Internal logic error",
+	BAD_REQUEST_ERROR:          "Bad request",
+	UNKNOWN_ERROR:              "Unknown error",
+	FORBIDDEN_ERROR:            "Forbidden",
+	UNPROCESSABLE_ENTITY_ERROR: "Unprocessable entity",
+	TOO_MANY_REQUESTS_ERROR:    "Too many requests",
+}
+
+var errorCodes = map[ErrorCause]string{
+	ENTITY_DUPLICATE:           "ENTITY_DUPLICATE",
+	ENTITY_NOT_FOUND:           "ENTITY_NOT_FOUND",
+	INTERNAL_SERVICE_ERROR:     "INTERNAL_SERVICE_ERROR",
+	CREDENTIALS_ERROR:          "CREDENTIALS_ERROR",
+	TOKEN_GENERATION_ERROR:     "TOKEN_GENERATION_ERROR",
+	TOKEN_EXPIRED_ERROR:        "TOKEN_EXPIRED_ERROR",
+	INTERNAL_LOGIC_ERROR:       "INTERNAL_LOGIC_ERROR",
+	BAD_REQUEST_ERROR:          "BAD_REQUEST_ERROR",
+	UNKNOWN_ERROR:              "UNKNOWN_ERROR",
+	FORBIDDEN_ERROR:            "FORBIDDEN_ERROR",
+	UNPROCESSABLE_ENTITY_ERROR: "UNPROCESSABLE_ENTITY_ERROR",
+	TOO_MANY_REQUESTS_ERROR:    "TOO_MANY_REQUESTS_ERROR",
+}
+
+// Code mengembalikan nama cause yang stabil untuk dikirim ke client
+func (e ErrorCause) Code() string {
+	if v, ok := errorCodes[e]; ok {
+		return v
+	}
+
+	return errorCodes[UNKNOWN_ERROR]
 }
 
 func (e ErrorCause) String() string {
@@ -33,3 +64,8 @@ func (e ErrorCause) String() string {
 
 	return ErrorMessages[UNKNOWN_ERROR]
 }
+
+// Error membuat ErrorCause bisa dipakai sebagai target errors.Is maupun dibungkus dengan Wrap
+func (e ErrorCause) Error() string {
+	return e.String()
+}
@@ -1,8 +1,14 @@
 package result
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -10,6 +16,10 @@ type ErrorTrace struct {
 	caller     string
 	message    string
 	cause      ErrorCause
+	messageId  string
+	args       []any
+	err        error
+	fields     map[string]any
 	IsExpected bool
 }
 
@@ -19,37 +29,107 @@ type Result[T any] struct {
 }
 
 func NewError[T any](message string, Expected ...bool) Result[T] {
-	return Err(Result[T]{}, message, Expected...)
+	return push(Result[T]{}, newTrace(message, nil, Expected))
 }
 
 func Err[T any](err Result[T], message string, Expected ...bool) Result[T] {
+	return push(err, newTrace(message, nil, Expected))
+}
+
+// Wrap membuat Result gagal yang membungkus err, misalnya error dari driver database,
+// supaya tetap bisa diperiksa dengan errors.Is dan errors.As. Cause diambil dari err
+// jika err adalah ErrorTrace atau ErrorCause, selain itu INTERNAL_SERVICE_ERROR.
+func Wrap[T any](err error, message string, Expected ...bool) Result[T] {
+	return push(Result[T]{}, newTrace(message, err, Expected))
+}
+
+// newTrace harus dipanggil langsung dari fungsi publik supaya caller menunjuk ke pemanggil fungsi tersebut
+func newTrace(message string, err error, expected []bool) *ErrorTrace {
 	_, file, line, _ := runtime.Caller(2)
-	caller := fmt.Sprintf("%s:%d", file, line)
 
 	var knErr bool
-	if len(Expected) > 0 {
-		knErr = Expected[0]
+	if len(expected) > 0 {
+		knErr = expected[0]
 	}
 
-	_error := &ErrorTrace{caller, message, INTERNAL_SERVICE_ERROR, knErr}
-	if err.errors == nil {
-		err.errors = make([]*ErrorTrace, 1, 4)
-		err.errors[0] = _error
-	} else {
-		err.errors = append(err.errors, _error)
+	return &ErrorTrace{
+		caller:     fmt.Sprintf("%s:%d", file, line),
+		message:    message,
+		cause:      causeOf(err),
+		err:        err,
+		IsExpected: knErr,
 	}
+}
 
-	return Result[T]{errors: err.errors}
+func push[T any](r Result[T], e *ErrorTrace) Result[T] {
+	if r.errors == nil {
+		r.errors = make([]*ErrorTrace, 0, 4)
+	}
+
+	return Result[T]{errors: append(r.errors, e)}
+}
+
+func causeOf(err error) ErrorCause {
+	var errTrace *ErrorTrace
+	if errors.As(err, &errTrace) {
+		return errTrace.cause
+	}
+
+	var cause ErrorCause
+	if errors.As(err, &cause) {
+		return cause
+	}
+
+	return INTERNAL_SERVICE_ERROR
+}
+
+// ErrorFrom membuat Result bertipe T dari error milik Result lain,
+// pesan, cause dan message id ikut disalin supaya tetap bisa diterjemahkan.
+func ErrorFrom[T any](e *ErrorTrace) Result[T] {
+	_, file, line, _ := runtime.Caller(1)
+
+	_error := *e
+	_error.caller = fmt.Sprintf("%s:%d", file, line)
+
+	return Result[T]{errors: []*ErrorTrace{&_error}}
 }
 
 func Ok[T any](v T) Result[T] {
 	return Result[T]{value: v, errors: nil}
 }
 
+// Map menerapkan fn pada nilai r jika r tidak gagal, error pada r diteruskan apa adanya
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.IsError() {
+		return Result[U]{errors: r.errors}
+	}
+
+	return Ok(fn(r.value))
+}
+
+// FlatMap sama seperti Map tetapi fn sendiri mengembalikan Result
+func FlatMap[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if r.IsError() {
+		return Result[U]{errors: r.errors}
+	}
+
+	return fn(r.value)
+}
+
 func (r Result[T]) Value() T {
 	return r.value
 }
 
+// Must mengembalikan nilai r dan panic jika r gagal.
+// Hanya untuk kode inisialisasi dan test.
+func (r Result[T]) Must() T {
+	if r.IsError() {
+		panic(r)
+	}
+
+	return r.value
+}
+
 func (r Result[T]) IsError() bool {
 	return len(r.errors) > 0
 }
@@ -77,12 +157,21 @@ func (r Result[T]) Error() string {
 
 	errorString := strings.Builder{}
 	for _, v := range r.errors {
-		errorString.WriteString(fmt.Sprintf("%s:\n\t%s\n", v.caller, v.message))
+		errorString.WriteString(fmt.Sprintf("%s:\n\t%s\n", v.caller, v.Error()))
 	}
 
 	return errorString.String()
 }
 
+// Unwrap mengembalikan root error supaya errors.Is dan errors.As bisa dipakai langsung pada Result
+func (r Result[T]) Unwrap() error {
+	if root := r.RootError(); root != nil {
+		return root
+	}
+
+	return nil
+}
+
 func (r Result[T]) ExpectedError() *ErrorTrace {
 	for _, err := range r.errors {
 		if err.IsExpected {
@@ -93,15 +182,143 @@ func (r Result[T]) ExpectedError() *ErrorTrace {
 	return nil
 }
 
+// WithCause mengubah cause pada root error, tidak berpengaruh jika r tidak gagal
 func (r Result[T]) WithCause(cause ErrorCause) Result[T] {
-	r.errors[0].cause = cause
+	if root := r.RootError(); root != nil {
+		root.cause = cause
+	}
+	return r
+}
+
+// WithMessage memberi message id dari katalog i18n pada error terakhir,
+// yaitu error yang baru dibuat oleh NewError atau Err. args mengisi format pesan.
+func (r Result[T]) WithMessage(id string, args ...any) Result[T] {
+	if last := r.LastError(); last != nil {
+		last.messageId = id
+		last.args = args
+	}
+	return r
+}
+
+// WithField menambahkan konteks key/value pada error terakhir, ikut tampil saat error ditulis ke log
+func (r Result[T]) WithField(key string, value any) Result[T] {
+	if last := r.LastError(); last != nil {
+		if last.fields == nil {
+			last.fields = make(map[string]any)
+		}
+		last.fields[key] = value
+	}
 	return r
 }
 
+func (r Result[T]) MarshalJSON() ([]byte, error) {
+	if r.IsError() {
+		return json.Marshal(map[string]any{"errors": r.errors})
+	}
+
+	return json.Marshal(map[string]any{"value": r.value})
+}
+
+// LogValue membuat Result tercatat sebagai field terstruktur oleh slog, setiap ErrorTrace menjadi satu group
+func (r Result[T]) LogValue() slog.Value {
+	if !r.IsError() {
+		return slog.AnyValue(r.value)
+	}
+
+	traces := make([]slog.Attr, len(r.errors))
+	for i, e := range r.errors {
+		traces[i] = slog.Any(strconv.Itoa(i), e)
+	}
+
+	return slog.GroupValue(
+		slog.String("message", r.LastError().Error()),
+		slog.String("cause", r.RootError().cause.Code()),
+		slog.Attr{Key: "trace", Value: slog.GroupValue(traces...)},
+	)
+}
+
 func (e ErrorTrace) Error() string {
+	if e.err != nil {
+		return e.message + ": " + e.err.Error()
+	}
+
 	return e.message
 }
 
+func (e ErrorTrace) Unwrap() error {
+	return e.err
+}
+
+// Is membuat errors.Is(err, ENTITY_NOT_FOUND) bernilai true jika cause error sama
+func (e ErrorTrace) Is(target error) bool {
+	cause, ok := target.(ErrorCause)
+	return ok && e.cause == cause
+}
+
+func (e ErrorTrace) Caller() string {
+	return e.caller
+}
+
 func (e ErrorTrace) Cause() ErrorCause {
 	return e.cause
 }
+
+func (e ErrorTrace) MessageId() string {
+	return e.messageId
+}
+
+func (e ErrorTrace) MessageArgs() []any {
+	return e.args
+}
+
+func (e ErrorTrace) Fields() map[string]any {
+	return e.fields
+}
+
+func (e ErrorTrace) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("caller", e.caller),
+		slog.String("message", e.message),
+		slog.String("cause", e.cause.Code()),
+		slog.Bool("is_expected", e.IsExpected),
+	}
+	if e.messageId != "" {
+		attrs = append(attrs, slog.String("message_id", e.messageId))
+	}
+	if e.err != nil {
+		attrs = append(attrs, slog.String("error", e.err.Error()))
+	}
+	if len(e.fields) > 0 {
+		fields := make([]slog.Attr, 0, len(e.fields))
+		for _, key := range slices.Sorted(maps.Keys(e.fields)) {
+			fields = append(fields, slog.Any(key, e.fields[key]))
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fields...)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+func (e ErrorTrace) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Caller     string         `json:"caller"`
+		Message    string         `json:"message"`
+		Cause      string         `json:"cause"`
+		MessageId  string         `json:"message_id,omitempty"`
+		Error      string         `json:"error,omitempty"`
+		Fields     map[string]any `json:"fields,omitempty"`
+		IsExpected bool           `json:"is_expected"`
+	}{
+		Caller:     e.caller,
+		Message:    e.message,
+		Cause:      e.cause.Code(),
+		MessageId:  e.messageId,
+		Fields:     e.fields,
+		IsExpected: e.IsExpected,
+	}
+	if e.err != nil {
+		out.Error = e.err.Error()
+	}
+
+	return json.Marshal(out)
+}
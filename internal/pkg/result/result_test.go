@@ -0,0 +1,131 @@
+package result
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	. "github.com/onsi/gomega"
+)
+
+func TestWrap_KeepsOriginalError(t *testing.T) {
+	g := NewWithT(t)
+	pgErr := &pgconn.PgError{Code: "23505", Message: "duplicate key"}
+
+	result := Wrap[int](pgErr, "database error")
+
+	var target *pgconn.PgError
+	g.Expect(errors.As(result, &target)).To(BeTrue())
+	g.Expect(target.Code).To(Equal("23505"))
+	g.Expect(result.RootError().Error()).To(Equal("database error: " + pgErr.Error()))
+	g.Expect(result.RootError().Cause()).To(Equal(INTERNAL_SERVICE_ERROR))
+	g.Expect(result.RootError().Caller()).To(ContainSubstring("result_test.go"))
+}
+
+func TestWrap_InheritsCause(t *testing.T) {
+	g := NewWithT(t)
+	inner := Wrap[int](sql.ErrNoRows, "user not found", true).WithCause(ENTITY_NOT_FOUND)
+
+	result := Wrap[string](inner, "Failed to get user")
+
+	g.Expect(result.RootError().Cause()).To(Equal(ENTITY_NOT_FOUND))
+	g.Expect(errors.Is(result, ENTITY_NOT_FOUND)).To(BeTrue())
+	g.Expect(errors.Is(result, sql.ErrNoRows)).To(BeTrue())
+	g.Expect(errors.Is(result, CREDENTIALS_ERROR)).To(BeFalse())
+	g.Expect(result.ExpectedError()).To(BeNil())
+}
+
+func TestWithCause_OkResult(t *testing.T) {
+	g := NewWithT(t)
+
+	result := Ok(1).WithCause(ENTITY_NOT_FOUND).WithMessage("USER_NOT_FOUND").WithField("id", 1)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(Equal(1))
+}
+
+func TestMapAndFlatMap(t *testing.T) {
+	g := NewWithT(t)
+
+	mapped := Map(Ok(21), func(v int) int { return v * 2 })
+	g.Expect(mapped.Value()).To(Equal(42))
+
+	parsed := FlatMap(Ok("x"), func(v string) Result[int] {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return Wrap[int](err, "invalid number", true).WithCause(BAD_REQUEST_ERROR)
+		}
+		return Ok(n)
+	})
+	g.Expect(parsed.IsError()).To(BeTrue())
+	g.Expect(errors.Is(parsed, BAD_REQUEST_ERROR)).To(BeTrue())
+
+	called := false
+	failed := Map(parsed, func(v int) string { called = true; return "" })
+	g.Expect(called).To(BeFalse())
+	g.Expect(failed.ExpectedError()).To(Equal(parsed.ExpectedError()))
+}
+
+func TestMust(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Ok("value").Must()).To(Equal("value"))
+	g.Expect(func() { NewError[string]("boom").Must() }).To(Panic())
+}
+
+func TestMarshalJSON(t *testing.T) {
+	g := NewWithT(t)
+
+	result := Wrap[int](sql.ErrNoRows, "oil record not found", true).
+		WithCause(ENTITY_NOT_FOUND).
+		WithMessage("OIL_NOT_FOUND").
+		WithField("oil_id", 7)
+
+	raw, err := json.Marshal(result)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var body struct {
+		Errors []map[string]any `json:"errors"`
+	}
+	g.Expect(json.Unmarshal(raw, &body)).To(Succeed())
+	g.Expect(body.Errors).To(HaveLen(1))
+	g.Expect(body.Errors[0]).To(HaveKeyWithValue("message", "oil record not found"))
+	g.Expect(body.Errors[0]).To(HaveKeyWithValue("cause", "ENTITY_NOT_FOUND"))
+	g.Expect(body.Errors[0]).To(HaveKeyWithValue("message_id", "OIL_NOT_FOUND"))
+	g.Expect(body.Errors[0]).To(HaveKeyWithValue("error", sql.ErrNoRows.Error()))
+	g.Expect(body.Errors[0]).To(HaveKeyWithValue("fields", HaveKeyWithValue("oil_id", BeNumerically("==", 7))))
+	g.Expect(body.Errors[0]).To(HaveKeyWithValue("is_expected", true))
+
+	raw, err = json.Marshal(Ok(7))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(raw)).To(Equal(`{"value":7}`))
+}
+
+func TestLogValue(t *testing.T) {
+	g := NewWithT(t)
+	buf := new(bytes.Buffer)
+	log := slog.New(slog.NewJSONHandler(buf, nil))
+
+	inner := Wrap[int](sql.ErrNoRows, "oil record not found", true).WithCause(ENTITY_NOT_FOUND).WithField("oil_id", 7)
+	log.Error("failed", "error", Err(inner, "Failed to get oil record"))
+
+	var line struct {
+		Error struct {
+			Message string                    `json:"message"`
+			Cause   string                    `json:"cause"`
+			Trace   map[string]map[string]any `json:"trace"`
+		} `json:"error"`
+	}
+	g.Expect(json.Unmarshal(buf.Bytes(), &line)).To(Succeed())
+	g.Expect(line.Error.Message).To(Equal("Failed to get oil record"))
+	g.Expect(line.Error.Cause).To(Equal("ENTITY_NOT_FOUND"))
+	g.Expect(line.Error.Trace).To(HaveLen(2))
+	g.Expect(line.Error.Trace["0"]).To(HaveKeyWithValue("error", sql.ErrNoRows.Error()))
+	g.Expect(line.Error.Trace["0"]).To(HaveKeyWithValue("fields", HaveKeyWithValue("oil_id", BeNumerically("==", 7))))
+	g.Expect(line.Error.Trace["1"]).To(HaveKeyWithValue("caller", ContainSubstring("result_test.go")))
+}
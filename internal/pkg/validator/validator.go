@@ -0,0 +1,155 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	playground "github.com/go-playground/validator/v10"
+)
+
+// FieldError adalah satu pelanggaran aturan validasi pada field request.
+// Field memakai nama dari tag json, atau tag query untuk query parameter, supaya sama dengan yang dikirim client.
+type FieldError struct {
+	Field     string `json:"field"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	MessageId string `json:"-"`
+	Args      []any  `json:"-"`
+}
+
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, e := range v {
+		messages[i] = e.Message
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Localize mengembalikan salinan v dengan pesan dalam bahasa lang
+func (v ValidationErrors) Localize(lang i18n.Language) ValidationErrors {
+	localized := make(ValidationErrors, len(v))
+	for i, e := range v {
+		localized[i] = e
+		if message, ok := i18n.Translate(lang, e.MessageId, e.Args...); ok {
+			localized[i].Message = message
+		}
+	}
+
+	return localized
+}
+
+var validate = newValidate()
+
+func newValidate() *playground.Validate {
+	v := playground.New(playground.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		tag := f.Tag.Get("json")
+		if tag == "" {
+			tag = f.Tag.Get("query")
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+
+	// notblank menolak string yang hanya berisi spasi
+	_ = v.RegisterValidation("notblank", func(fl playground.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+
+	return v
+}
+
+// Validate menjalankan aturan dari tag validate pada struct v.
+// Hasilnya nil jika v valid, selain itu berisi ValidationErrors.
+func Validate(v any) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs playground.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	result := make(ValidationErrors, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		id, args := messageOf(fe)
+		message, _ := i18n.Translate(i18n.DEFAULT_LANGUAGE, id, args...)
+
+		result[i] = FieldError{
+			Field:     fe.Field(),
+			Code:      fe.Tag(),
+			Message:   message,
+			MessageId: id,
+			Args:      args,
+		}
+	}
+
+	return result
+}
+
+func messageOf(fe playground.FieldError) (string, []any) {
+	field := fe.Field()
+
+	switch fe.Tag() {
+	case "required", "notblank", "required_without":
+		return i18n.VALIDATION_REQUIRED, []any{field}
+	case "email":
+		return i18n.VALIDATION_EMAIL, []any{field}
+	case "uuid":
+		return i18n.VALIDATION_UUID, []any{field}
+	case "oneof":
+		return i18n.VALIDATION_ONEOF, []any{field, strings.Join(strings.Fields(fe.Param()), ", ")}
+	case "gt":
+		return i18n.VALIDATION_GT, []any{field, fe.Param()}
+	case "gte":
+		return i18n.VALIDATION_GTE, []any{field, fe.Param()}
+	case "min":
+		if fe.Kind() == reflect.String {
+			return i18n.VALIDATION_MIN_LENGTH, []any{field, fe.Param()}
+		}
+		return i18n.VALIDATION_MIN_ITEMS, []any{field, fe.Param()}
+	case "max":
+		if fe.Kind() == reflect.String {
+			return i18n.VALIDATION_MAX_LENGTH, []any{field, fe.Param()}
+		}
+		return i18n.VALIDATION_MAX_ITEMS, []any{field, fe.Param()}
+	case "gtefield":
+		// pesan "tidak boleh sebelum" hanya cocok untuk tanggal
+		if fe.Kind() != reflect.Struct {
+			return i18n.VALIDATION_GTE, []any{field, snakeCase(fe.Param())}
+		}
+		return i18n.VALIDATION_GTEFIELD, []any{field, snakeCase(fe.Param())}
+	case "datetime":
+		return i18n.VALIDATION_DATE, []any{field}
+	default:
+		return i18n.VALIDATION_INVALID, []any{field}
+	}
+}
+
+// snakeCase mengubah nama field Go pada param tag *field menjadi nama json, misal StartDate menjadi start_date
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+
+	return strings.ToLower(b.String())
+}
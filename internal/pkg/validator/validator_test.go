@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	. "github.com/onsi/gomega"
+)
+
+type testRequest struct {
+	Email     string    `json:"email" validate:"required,email"`
+	Volume    float64   `json:"oil_volume" validate:"required,gt=0"`
+	Type      string    `json:"transaction_type" validate:"required,oneof=SELL BUY"`
+	Reason    string    `json:"reason" validate:"notblank,max=5"`
+	StartDate time.Time `json:"start_date" validate:"required"`
+	EndDate   time.Time `json:"end_date" validate:"required,gtefield=StartDate"`
+}
+
+func validRequest() testRequest {
+	now := time.Now()
+	return testRequest{
+		Email:     "seller@example.com",
+		Volume:    10,
+		Type:      "SELL",
+		Reason:    "typo",
+		StartDate: now,
+		EndDate:   now.Add(time.Hour),
+	}
+}
+
+// strip membuang MessageId dan Args supaya hasil validasi mudah dibandingkan
+func strip(errs ValidationErrors) []FieldError {
+	stripped := make([]FieldError, len(errs))
+	for i, e := range errs {
+		stripped[i] = FieldError{Field: e.Field, Code: e.Code, Message: e.Message}
+	}
+	return stripped
+}
+
+func TestValidate_Valid(t *testing.T) {
+	g := NewWithT(t)
+	req := validRequest()
+
+	g.Expect(Validate(&req)).To(Succeed())
+}
+
+func TestValidate_FieldErrors(t *testing.T) {
+	g := NewWithT(t)
+	req := validRequest()
+	req.Email = "not-an-email"
+	req.Volume = -1
+	req.Type = "GIFT"
+	req.Reason = "   "
+	req.EndDate = req.StartDate.Add(-time.Hour)
+
+	err := Validate(&req)
+
+	var fieldErrs ValidationErrors
+	g.Expect(errors.As(err, &fieldErrs)).To(BeTrue())
+	g.Expect(strip(fieldErrs)).To(ConsistOf(
+		FieldError{Field: "email", Code: "email", Message: "email must be a valid email address"},
+		FieldError{Field: "oil_volume", Code: "gt", Message: "oil_volume must be greater than 0"},
+		FieldError{Field: "transaction_type", Code: "oneof", Message: "transaction_type must be one of SELL, BUY"},
+		FieldError{Field: "reason", Code: "notblank", Message: "reason is required"},
+		FieldError{Field: "end_date", Code: "gtefield", Message: "end_date must not be before start_date"},
+	))
+}
+
+func TestValidate_Required(t *testing.T) {
+	g := NewWithT(t)
+
+	err := Validate(&testRequest{})
+
+	var fieldErrs ValidationErrors
+	g.Expect(errors.As(err, &fieldErrs)).To(BeTrue())
+	g.Expect(strip(fieldErrs)).To(ContainElement(FieldError{Field: "email", Code: "required", Message: "email is required"}))
+	g.Expect(strip(fieldErrs)).To(ContainElement(FieldError{Field: "oil_volume", Code: "required", Message: "oil_volume is required"}))
+}
+
+type testQuery struct {
+	MinVolume float64 `query:"min_volume" validate:"gte=0"`
+	MaxVolume float64 `query:"max_volume" validate:"omitempty,gtefield=MinVolume"`
+	StartDate string  `query:"start_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func TestValidate_QueryFieldNames(t *testing.T) {
+	g := NewWithT(t)
+
+	err := Validate(&testQuery{MinVolume: 10, MaxVolume: 5, StartDate: "01-02-2025"})
+
+	var fieldErrs ValidationErrors
+	g.Expect(errors.As(err, &fieldErrs)).To(BeTrue())
+	g.Expect(strip(fieldErrs)).To(ConsistOf(
+		FieldError{Field: "max_volume", Code: "gtefield", Message: "max_volume must be greater than or equal to min_volume"},
+		FieldError{Field: "start_date", Code: "datetime", Message: "start_date must be a date in YYYY-MM-DD format"},
+	))
+}
+
+func TestValidationErrors_Localize(t *testing.T) {
+	g := NewWithT(t)
+	req := validRequest()
+	req.Volume = 0
+	req.Type = "GIFT"
+
+	var fieldErrs ValidationErrors
+	g.Expect(errors.As(Validate(&req), &fieldErrs)).To(BeTrue())
+
+	localized := strip(fieldErrs.Localize(i18n.ID))
+
+	g.Expect(localized).To(ConsistOf(
+		FieldError{Field: "oil_volume", Code: "required", Message: "oil_volume wajib diisi"},
+		FieldError{Field: "transaction_type", Code: "oneof", Message: "transaction_type harus salah satu dari SELL, BUY"},
+	))
+	g.Expect(fieldErrs[0].Message).To(Equal("oil_volume is required"))
+}
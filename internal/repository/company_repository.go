@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+)
+
+type ICompanyRepository interface {
+	FindById(ctx context.Context, id int64) Result[*entity.UserWithCompany]
+	// collectorId 0 berarti semua collector
+	CountDeliveries(ctx context.Context, companyId, collectorId int64) Result[int64]
+	ListDeliveries(ctx context.Context, companyId, collectorId int64, limit, offset int) Result[[]entity.CompanyDelivery]
+	FindDelivery(ctx context.Context, companyId, id int64) Result[*entity.CompanyDelivery]
+	// AcknowledgeDelivery mengembalikan false jika pengiriman sudah pernah dikonfirmasi
+	AcknowledgeDelivery(ctx context.Context, companyId, id int64) Result[bool]
+	GetPeriodAggregates(ctx context.Context, companyId int64, period string, start, end time.Time) Result[[]entity.PeriodAggregate]
+}
+
+type CompanyRepository struct {
+	db services.DatabaseService
+}
+
+var _ ICompanyRepository = (*CompanyRepository)(nil)
+
+func NewCompanyRepository(db services.DatabaseService) ICompanyRepository {
+	return &CompanyRepository{db}
+}
+
+func (r *CompanyRepository) FindById(ctx context.Context, id int64) Result[*entity.UserWithCompany] {
+	rows := r.db.QueryRowxContext(ctx, companyFindById, id)
+	company := new(entity.UserWithCompany)
+
+	err := rows.StructScan(company)
+	if err != nil {
+		return handleUserError[*entity.UserWithCompany](ctx, err)
+	}
+
+	return Ok(company)
+}
+
+func (r *CompanyRepository) CountDeliveries(ctx context.Context, companyId, collectorId int64) Result[int64] {
+	var total int64
+
+	err := r.db.QueryRowxContext(ctx, companyDeliveryCount, companyId, collectorId).Scan(&total)
+	if err != nil {
+		return handleTransactionError[int64](ctx, err)
+	}
+
+	return Ok(total)
+}
+
+func (r *CompanyRepository) ListDeliveries(ctx context.Context, companyId, collectorId int64, limit, offset int) Result[[]entity.CompanyDelivery] {
+	var deliveries []entity.CompanyDelivery
+
+	err := r.db.SelectContext(ctx, &deliveries, companyDeliveryList, companyId, collectorId, limit, offset)
+	if err != nil {
+		return handleTransactionError[[]entity.CompanyDelivery](ctx, err)
+	}
+
+	return Ok(deliveries)
+}
+
+func (r *CompanyRepository) FindDelivery(ctx context.Context, companyId, id int64) Result[*entity.CompanyDelivery] {
+	delivery := new(entity.CompanyDelivery)
+
+	err := r.db.QueryRowxContext(ctx, companyDeliveryFindById, id, companyId).StructScan(delivery)
+	if err != nil {
+		return handleTransactionError[*entity.CompanyDelivery](ctx, err)
+	}
+
+	return Ok(delivery)
+}
+
+func (r *CompanyRepository) AcknowledgeDelivery(ctx context.Context, companyId, id int64) Result[bool] {
+	res, err := r.db.ExecContext(ctx, companyDeliveryAcknowledge, id, companyId)
+	if err != nil {
+		return handleTransactionError[bool](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return Ok(rowsAffected > 0)
+}
+
+func (r *CompanyRepository) GetPeriodAggregates(ctx context.Context, companyId int64, period string, start, end time.Time) Result[[]entity.PeriodAggregate] {
+	var aggregates []entity.PeriodAggregate
+
+	err := r.db.SelectContext(ctx, &aggregates, companyPeriodAggregate, companyId, period, start, end)
+	if err != nil {
+		return handleTransactionError[[]entity.PeriodAggregate](ctx, err)
+	}
+
+	return Ok(aggregates)
+}
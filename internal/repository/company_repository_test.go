@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+)
+
+func TestCompanyRepository_ListDeliveries_FilterByCollector(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewCompanyRepository(dbService)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "collector_id", "collector_name", "volume", "price", "total_amount", "received_at", "created_at"}).
+		AddRow(5, 20, "Test Collector", 100, 7000, 700000, now, now).
+		AddRow(4, 20, "Test Collector", 50, 7000, 350000, nil, now.Add(-time.Hour))
+
+	mock.ExpectQuery(`FROM "DistributeTransaction" dt\s+JOIN "Collector" c`).
+		WithArgs(int64(3), int64(20), 20, 0).
+		WillReturnRows(rows)
+
+	result := repo.ListDeliveries(ctx, 3, 20, 20, 0)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(HaveLen(2))
+	g.Expect(result.Value()[0].IsReceived()).To(BeTrue())
+	g.Expect(result.Value()[1].IsReceived()).To(BeFalse())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestCompanyRepository_AcknowledgeDelivery_AlreadyReceived(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewCompanyRepository(dbService)
+	ctx := context.Background()
+
+	mock.ExpectExec(`UPDATE "DistributeTransaction" SET received_at = NOW\(\)`).
+		WithArgs(int64(5), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	result := repo.AcknowledgeDelivery(ctx, 3, 5)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(BeFalse())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
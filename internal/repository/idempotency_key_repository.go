@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type IIdempotencyKeyRepository interface {
+	// Claim mengembalikan false jika key masih dipakai oleh request sebelumnya
+	Claim(ctx context.Context, collectorId int64, key string, requestHash string, expiresAt time.Time) Result[bool]
+	Find(ctx context.Context, collectorId int64, key string) Result[*entity.IdempotencyKey]
+	SaveResponse(ctx context.Context, collectorId int64, key string, responseBody []byte) Result[bool]
+	DeleteExpired(ctx context.Context) Result[int64]
+}
+
+type IdempotencyKeyRepository struct {
+	db services.DatabaseService
+}
+
+var _ IIdempotencyKeyRepository = (*IdempotencyKeyRepository)(nil)
+
+func NewIdempotencyKeyRepository(db services.DatabaseService) IIdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db}
+}
+
+func (r *IdempotencyKeyRepository) Claim(ctx context.Context, collectorId int64, key string, requestHash string, expiresAt time.Time) Result[bool] {
+	var id int64
+
+	err := r.db.QueryRowxContext(ctx, idempotencyKeyClaim, collectorId, key, requestHash, expiresAt).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Ok(false)
+	}
+	if err != nil {
+		return handleIdempotencyKeyError[bool](ctx, err)
+	}
+
+	return Ok(true)
+}
+
+func (r *IdempotencyKeyRepository) Find(ctx context.Context, collectorId int64, key string) Result[*entity.IdempotencyKey] {
+	idempotencyKey := new(entity.IdempotencyKey)
+
+	err := r.db.QueryRowxContext(ctx, idempotencyKeyFind, collectorId, key).StructScan(idempotencyKey)
+	if err != nil {
+		return handleIdempotencyKeyError[*entity.IdempotencyKey](ctx, err)
+	}
+
+	return Ok(idempotencyKey)
+}
+
+func (r *IdempotencyKeyRepository) SaveResponse(ctx context.Context, collectorId int64, key string, responseBody []byte) Result[bool] {
+	res, err := r.db.ExecContext(ctx, idempotencyKeySaveResponse, collectorId, key, string(responseBody))
+	if err != nil {
+		return handleIdempotencyKeyError[bool](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected <= 0 {
+		return NewError[bool]("idempotency key not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.IDEMPOTENCY_KEY_NOT_FOUND)
+	}
+
+	return Ok(true)
+}
+
+func (r *IdempotencyKeyRepository) DeleteExpired(ctx context.Context) Result[int64] {
+	res, err := r.db.ExecContext(ctx, idempotencyKeyDeleteExpired)
+	if err != nil {
+		return handleIdempotencyKeyError[int64](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return Ok(rowsAffected)
+}
+
+func handleIdempotencyKeyError[T any](ctx context.Context, err error) Result[T] {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503":
+			return NewError[T]("invalid collector_id", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.INVALID_COLLECTOR_ID)
+		default:
+			logger.FromContext(ctx).Error("database error", "error", err)
+			return Wrap[T](err, "database error")
+		}
+	} else if errors.Is(err, sql.ErrNoRows) {
+		return NewError[T]("idempotency key not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.IDEMPOTENCY_KEY_NOT_FOUND)
+	}
+
+	logger.FromContext(ctx).Error("database error", "error", err)
+
+	return Wrap[T](err, "database error")
+}
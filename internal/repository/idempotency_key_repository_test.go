@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+)
+
+func TestIdempotencyKeyRepository_Claim(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	cases := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		claimed bool
+	}{
+		{"new key", sqlmock.NewRows([]string{"collector_id"}).AddRow(7), true},
+		{"key still in use", sqlmock.NewRows([]string{"collector_id"}), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockDB, mock, dbService := setupMockDB(t)
+			defer mockDB.Close()
+
+			repo := NewIdempotencyKeyRepository(dbService)
+
+			mock.ExpectQuery(`INSERT INTO "IdempotencyKey"`).
+				WithArgs(int64(7), "retry-1", "hash", expiresAt).
+				WillReturnRows(tc.rows)
+
+			result := repo.Claim(context.Background(), 7, "retry-1", "hash", expiresAt)
+
+			g.Expect(result.IsError()).To(BeFalse())
+			g.Expect(result.Value()).To(Equal(tc.claimed))
+			g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	}
+}
+
+func TestIdempotencyKeyRepository_Find_Completed(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewIdempotencyKeyRepository(dbService)
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT \* FROM "IdempotencyKey"`).
+		WithArgs(int64(7), "retry-1").
+		WillReturnRows(sqlmock.NewRows([]string{"collector_id", "idempotency_key", "request_hash", "response_body", "created_at", "expires_at"}).
+			AddRow(7, "retry-1", "hash", []byte(`{"id":1}`), now, now.Add(time.Hour)))
+
+	result := repo.Find(context.Background(), 7, "retry-1")
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().IsCompleted()).To(BeTrue())
+	g.Expect(string(result.Value().ResponseBody)).To(Equal(`{"id":1}`))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+)
+
+type ILoginAttemptRepository interface {
+	// Find mengembalikan attempt dengan Failures 0 jika key belum pernah gagal atau sudah expired
+	Find(ctx context.Context, key string, now time.Time) Result[*entity.LoginAttempt]
+	// Increment menambah hitungan gagal secara atomik dan mengembalikan hitungan terbaru
+	Increment(ctx context.Context, key string, now time.Time, expiresAt time.Time) Result[int]
+	Lock(ctx context.Context, key string, until time.Time) Result[bool]
+	Reset(ctx context.Context, key string) Result[bool]
+	DeleteExpired(ctx context.Context) Result[int64]
+}
+
+type LoginAttemptRepository struct {
+	db services.DatabaseService
+}
+
+var _ ILoginAttemptRepository = (*LoginAttemptRepository)(nil)
+
+func NewLoginAttemptRepository(db services.DatabaseService) ILoginAttemptRepository {
+	return &LoginAttemptRepository{db}
+}
+
+func (r *LoginAttemptRepository) Find(ctx context.Context, key string, now time.Time) Result[*entity.LoginAttempt] {
+	attempt := new(entity.LoginAttempt)
+
+	err := r.db.QueryRowxContext(ctx, loginAttemptFind, key, now).StructScan(attempt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Ok(&entity.LoginAttempt{AttemptKey: key})
+	}
+	if err != nil {
+		return handleLoginAttemptError[*entity.LoginAttempt](ctx, err)
+	}
+
+	return Ok(attempt)
+}
+
+func (r *LoginAttemptRepository) Increment(ctx context.Context, key string, now time.Time, expiresAt time.Time) Result[int] {
+	var failures int
+
+	err := r.db.QueryRowxContext(ctx, loginAttemptIncrement, key, now, expiresAt).Scan(&failures)
+	if err != nil {
+		return handleLoginAttemptError[int](ctx, err)
+	}
+
+	return Ok(failures)
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) Result[bool] {
+	_, err := r.db.ExecContext(ctx, loginAttemptLock, key, until)
+	if err != nil {
+		return handleLoginAttemptError[bool](ctx, err)
+	}
+
+	return Ok(true)
+}
+
+func (r *LoginAttemptRepository) Reset(ctx context.Context, key string) Result[bool] {
+	_, err := r.db.ExecContext(ctx, loginAttemptDelete, key)
+	if err != nil {
+		return handleLoginAttemptError[bool](ctx, err)
+	}
+
+	return Ok(true)
+}
+
+func (r *LoginAttemptRepository) DeleteExpired(ctx context.Context) Result[int64] {
+	res, err := r.db.ExecContext(ctx, loginAttemptDeleteExpired)
+	if err != nil {
+		return handleLoginAttemptError[int64](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return Ok(rowsAffected)
+}
+
+func handleLoginAttemptError[T any](ctx context.Context, err error) Result[T] {
+	logger.FromContext(ctx).Error("database error", "error", err)
+
+	return Wrap[T](err, "database error")
+}
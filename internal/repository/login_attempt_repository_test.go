@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+)
+
+func TestLoginAttemptRepository_Find_NotFound(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewLoginAttemptRepository(dbService)
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT \* FROM "LoginAttempt"`).
+		WithArgs("email:a@example.com", now).
+		WillReturnRows(sqlmock.NewRows([]string{"attempt_key", "failures", "locked_until", "expires_at"}))
+
+	result := repo.Find(context.Background(), "email:a@example.com", now)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().AttemptKey).To(Equal("email:a@example.com"))
+	g.Expect(result.Value().Failures).To(BeZero())
+	g.Expect(result.Value().RetryAfter(now)).To(BeZero())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestLoginAttemptRepository_Find_Locked(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewLoginAttemptRepository(dbService)
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT \* FROM "LoginAttempt"`).
+		WithArgs("ip:10.0.0.1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"attempt_key", "failures", "locked_until", "expires_at"}).
+			AddRow("ip:10.0.0.1", 20, now.Add(time.Minute), now.Add(time.Hour)))
+
+	result := repo.Find(context.Background(), "ip:10.0.0.1", now)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().Failures).To(Equal(20))
+	g.Expect(result.Value().RetryAfter(now)).To(Equal(time.Minute))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestLoginAttemptRepository_Increment(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewLoginAttemptRepository(dbService)
+
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	mock.ExpectQuery(`INSERT INTO "LoginAttempt"`).
+		WithArgs("email:a@example.com", now, expiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"failures"}).AddRow(3))
+
+	result := repo.Increment(context.Background(), "email:a@example.com", now, expiresAt)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(Equal(3))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
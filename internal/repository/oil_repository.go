@@ -4,17 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strconv"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services"
 	"github.com/crazydw4rf/oil-bank-backend/internal/types"
-	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type IOilRepository interface {
 	types.BaseRepository[entity.Oil]
 	GetByCollectorId(ctx context.Context, collectorId int64) Result[*entity.Oil]
+	// AdjustVolume mengunci baris Oil milik collector lalu menambahkan (added - removed) ke total_volume.
+	// Ditolak dengan BAD_REQUEST_ERROR jika stok akan menjadi negatif.
+	AdjustVolume(ctx context.Context, collectorId int64, added, removed float64) Result[float64]
 }
 
 type OilRepository struct {
@@ -32,7 +38,7 @@ func (r *OilRepository) Create(ctx context.Context, oil *entity.Oil) Result[*ent
 
 	err := rows.StructScan(oil)
 	if err != nil {
-		return handleOilError[*entity.Oil](err)
+		return handleOilError[*entity.Oil](ctx, err)
 	}
 
 	return Ok(oil)
@@ -44,7 +50,7 @@ func (r *OilRepository) Find(ctx context.Context, id int64) Result[*entity.Oil]
 
 	err := rows.StructScan(oil)
 	if err != nil {
-		return handleOilError[*entity.Oil](err)
+		return handleOilError[*entity.Oil](ctx, err)
 	}
 
 	return Ok(oil)
@@ -53,12 +59,12 @@ func (r *OilRepository) Find(ctx context.Context, id int64) Result[*entity.Oil]
 func (r *OilRepository) GetByCollectorId(ctx context.Context, collectorId int64) Result[*entity.Oil] {
 	rows := r.db.QueryRowxContext(ctx, oilGetByCollectorId, collectorId)
 	if err := rows.Err(); err != nil {
-		return handleOilError[*entity.Oil](err)
+		return handleOilError[*entity.Oil](ctx, err)
 	}
 	oil := new(entity.Oil)
 	err := rows.StructScan(oil)
 	if err != nil {
-		return handleOilError[*entity.Oil](err)
+		return handleOilError[*entity.Oil](ctx, err)
 	}
 
 	return Ok(oil)
@@ -72,40 +78,64 @@ func (r *OilRepository) Update(ctx context.Context, oil *entity.Oil) Result[*ent
 
 	err := rows.StructScan(oil)
 	if err != nil {
-		return handleOilError[*entity.Oil](err)
+		return handleOilError[*entity.Oil](ctx, err)
 	}
 
 	return Ok(oil)
 }
 
+func (r *OilRepository) AdjustVolume(ctx context.Context, collectorId int64, added, removed float64) Result[float64] {
+	oil := new(entity.Oil)
+	if err := r.db.QueryRowxContext(ctx, oilLockByCollectorId, collectorId).StructScan(oil); err != nil {
+		return handleOilError[float64](ctx, err)
+	}
+
+	var totalVolume float64
+	err := r.db.QueryRowxContext(ctx, oilAdjustVolume, collectorId,
+		strconv.FormatFloat(added, 'f', -1, 64),
+		strconv.FormatFloat(removed, 'f', -1, 64),
+	).Scan(&totalVolume)
+	if errors.Is(err, sql.ErrNoRows) {
+		return NewError[float64]("insufficient oil inventory", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INSUFFICIENT_OIL_INVENTORY)
+	}
+	if err != nil {
+		return handleOilError[float64](ctx, err)
+	}
+
+	return Ok(totalVolume)
+}
+
 func (r *OilRepository) Delete(ctx context.Context, id int64) Result[bool] {
 	res, err := r.db.ExecContext(ctx, oilDelete, id)
 	if err != nil {
-		return handleOilError[bool](err)
+		return handleOilError[bool](ctx, err)
 	}
 
 	rowsAffected, _ := res.RowsAffected()
 	if rowsAffected <= 0 {
-		return NewError[bool]("oil record not found", true).WithCause(ENTITY_NOT_FOUND)
+		return NewError[bool]("oil record not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.OIL_NOT_FOUND)
 	}
 
 	return Ok(true)
 }
 
-func handleOilError[T any](err error) Result[T] {
-	var pgErr pgx.PgError
+func handleOilError[T any](ctx context.Context, err error) Result[T] {
+	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
 		case "23503":
-			return NewError[T]("invalid collector_id", true).WithCause(ENTITY_NOT_FOUND)
+			return NewError[T]("invalid collector_id", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.INVALID_COLLECTOR_ID)
 		case "23505":
-			return NewError[T]("oil record already exists for this collector", true).WithCause(ENTITY_DUPLICATE)
+			return NewError[T]("oil record already exists for this collector", true).WithCause(ENTITY_DUPLICATE).WithMessage(i18n.OIL_ALREADY_EXISTS)
 		default:
-			return NewError[T]("database error: " + err.Error()).WithCause(INTERNAL_SERVICE_ERROR)
+			logger.FromContext(ctx).Error("database error", "error", err)
+			return Wrap[T](err, "database error")
 		}
 	} else if errors.Is(err, sql.ErrNoRows) {
-		return NewError[T]("oil record not found", true).WithCause(ENTITY_NOT_FOUND)
+		return NewError[T]("oil record not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.OIL_NOT_FOUND)
 	}
 
-	return NewError[T]("database error: " + err.Error()).WithCause(INTERNAL_SERVICE_ERROR)
+	logger.FromContext(ctx).Error("database error", "error", err)
+
+	return Wrap[T](err, "database error")
 }
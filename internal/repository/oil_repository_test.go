@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	. "github.com/onsi/gomega"
+)
+
+func TestOilRepository_AdjustVolume_Success(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewOilRepository(dbService)
+	ctx := context.Background()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT \* FROM "Oil" WHERE collector_id = \$1 FOR UPDATE`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "collector_id", "total_volume", "created_at", "updated_at"}).AddRow(1, 7, 10, now, now))
+	mock.ExpectQuery(`UPDATE "Oil" SET total_volume = total_volume \+`).
+		WithArgs(int64(7), "100", "10.5").
+		WillReturnRows(sqlmock.NewRows([]string{"total_volume"}).AddRow(99.5))
+
+	result := repo.AdjustVolume(ctx, 7, 100, 10.5)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(Equal(99.5))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestOilRepository_AdjustVolume_InsufficientInventory(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewOilRepository(dbService)
+	ctx := context.Background()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT \* FROM "Oil" WHERE collector_id = \$1 FOR UPDATE`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "collector_id", "total_volume", "created_at", "updated_at"}).AddRow(1, 7, 5, now, now))
+	mock.ExpectQuery(`UPDATE "Oil" SET total_volume = total_volume \+`).
+		WithArgs(int64(7), "10", "50").
+		WillReturnRows(sqlmock.NewRows([]string{"total_volume"}))
+
+	result := repo.AdjustVolume(ctx, 7, 10, 50)
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.RootError().Cause()).To(Equal(BAD_REQUEST_ERROR))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
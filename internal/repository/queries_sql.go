@@ -31,22 +31,64 @@ updated_at = NOW() WHERE id = $1 RETURNING *`
 		WHERE u.email = $1
 		LIMIT 1`
 
-	sellTransactionCreate = `INSERT INTO "SellTransaction" (seller_id, collector_id, volume, price)
-		VALUES ($1, $2, $3, $4) RETURNING *`
+	userFindByEmailWithProfile = `
+		SELECT u.*,
+			COALESCE(s.id, c.id, co.id, 0) as profile_id,
+			COALESCE(s.seller_name, c.collector_name, co.company_name, '') as profile_name
+		FROM "User" u
+		LEFT JOIN "Seller" s ON u.id = s.user_id AND u.user_type = 'SELLER'
+		LEFT JOIN "Collector" c ON u.id = c.user_id AND u.user_type = 'COLLECTOR'
+		LEFT JOIN "Company" co ON u.id = co.user_id AND u.user_type = 'COMPANY'
+		WHERE u.email = $1
+		LIMIT 1`
 
-	distributeTransactionCreate = `INSERT INTO "DistributeTransaction" (collector_id, company_id, volume, price)
-		VALUES ($1, $2, $3, $4) RETURNING *`
+	userFindWithProfile = `
+		SELECT u.*,
+			COALESCE(s.id, c.id, co.id, 0) as profile_id,
+			COALESCE(s.seller_name, c.collector_name, co.company_name, '') as profile_name
+		FROM "User" u
+		LEFT JOIN "Seller" s ON u.id = s.user_id AND u.user_type = 'SELLER'
+		LEFT JOIN "Collector" c ON u.id = c.user_id AND u.user_type = 'COLLECTOR'
+		LEFT JOIN "Company" co ON u.id = co.user_id AND u.user_type = 'COMPANY'
+		WHERE u.id = $1
+		LIMIT 1`
+
+	// created_at diisi NOW() jika $5 NULL, transaksi dari sync offline memakai waktu dari perangkat
+	sellTransactionCreate = `INSERT INTO "SellTransaction" (seller_id, collector_id, volume, price, created_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, NOW())) RETURNING *`
+
+	distributeTransactionCreate = `INSERT INTO "DistributeTransaction" (collector_id, company_id, volume, price, created_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, NOW())) RETURNING *`
 
 	sellTransactionUpdate = `UPDATE "SellTransaction" SET volume = $2, price = $3, updated_at = NOW()
-		WHERE id = $1 RETURNING *`
+		WHERE id = $1 AND cancelled_at IS NULL RETURNING *`
 
 	distributeTransactionUpdate = `UPDATE "DistributeTransaction" SET volume = $2, price = $3, updated_at = NOW()
-		WHERE id = $1 RETURNING *`
+		WHERE id = $1 AND cancelled_at IS NULL RETURNING *`
+
+	// inventory dikembalikan oleh trigger trg_reverse_oil_on_*_cancel
+	sellTransactionCancel = `UPDATE "SellTransaction" SET cancelled_at = NOW(), cancel_reason = $2, updated_at = NOW()
+		WHERE id = $1 AND cancelled_at IS NULL RETURNING *`
+
+	distributeTransactionCancel = `UPDATE "DistributeTransaction" SET cancelled_at = NOW(), cancel_reason = $2, updated_at = NOW()
+		WHERE id = $1 AND cancelled_at IS NULL RETURNING *`
 
 	sellTransactionFindById = `SELECT * FROM "SellTransaction" WHERE id = $1 LIMIT 1`
 
+	sellTransactionLockById = `SELECT * FROM "SellTransaction" WHERE id = $1 FOR UPDATE`
+
 	distributeTransactionFindById = `SELECT * FROM "DistributeTransaction" WHERE id = $1 LIMIT 1`
 
+	distributeTransactionLockById = `SELECT * FROM "DistributeTransaction" WHERE id = $1 FOR UPDATE`
+
+	oilLockByCollectorId = `SELECT * FROM "Oil" WHERE collector_id = $1 FOR UPDATE`
+
+	// $2 volume yang ditambahkan, $3 volume yang dikurangi. Dikirim sebagai teks supaya
+	// selisihnya dihitung sebagai DECIMAL, tidak ada baris yang berubah jika stok menjadi negatif
+	oilAdjustVolume = `UPDATE "Oil" SET total_volume = total_volume + ($2::numeric - $3::numeric), updated_at = NOW()
+		WHERE collector_id = $1 AND total_volume + ($2::numeric - $3::numeric) >= 0
+		RETURNING total_volume`
+
 	updateCollectorVolume = `UPDATE "Collector" SET total_volume = COALESCE(total_volume, 0) + $2, updated_at = NOW()
 		WHERE id = $1`
 
@@ -59,7 +101,7 @@ updated_at = NOW() WHERE id = $1 RETURNING *`
 	FROM "SellTransaction" st
 	JOIN "Seller" s ON st.seller_id = s.id
 	JOIN "Collector" c ON st.collector_id = c.id
-	WHERE st.created_at >= $1 AND st.created_at <= $2
+	WHERE st.created_at >= $1 AND st.created_at <= $2 AND st.cancelled_at IS NULL
 	ORDER BY st.created_at DESC`
 
 	reportPurchasesByDate = `SELECT
@@ -71,7 +113,7 @@ updated_at = NOW() WHERE id = $1 RETURNING *`
 	FROM "DistributeTransaction" dt
 	JOIN "Collector" c ON dt.collector_id = c.id
 	JOIN "Company" co ON dt.company_id = co.id
-	WHERE dt.created_at >= $1 AND dt.created_at <= $2
+	WHERE dt.created_at >= $1 AND dt.created_at <= $2 AND dt.cancelled_at IS NULL
 	ORDER BY dt.created_at DESC`
 
 	reportAllSales = `SELECT
@@ -83,6 +125,7 @@ updated_at = NOW() WHERE id = $1 RETURNING *`
 	FROM "SellTransaction" st
 	JOIN "Seller" s ON st.seller_id = s.id
 	JOIN "Collector" c ON st.collector_id = c.id
+	WHERE st.cancelled_at IS NULL
 	ORDER BY st.created_at DESC`
 
 	reportAllPurchases = `SELECT
@@ -94,6 +137,7 @@ updated_at = NOW() WHERE id = $1 RETURNING *`
 	FROM "DistributeTransaction" dt
 	JOIN "Collector" c ON dt.collector_id = c.id
 	JOIN "Company" co ON dt.company_id = co.id
+	WHERE dt.cancelled_at IS NULL
 	ORDER BY dt.created_at DESC`
 
 	oilCreate = `INSERT INTO "Oil" (collector_id, total_volume)
@@ -109,4 +153,212 @@ updated_at = NOW() WHERE id = $1 RETURNING *`
 		WHERE id = $1 RETURNING *`
 
 	oilDelete = `DELETE FROM "Oil" WHERE id = $1`
+
+	refreshTokenCreate = `INSERT INTO "RefreshToken" (user_id, token_id, family_id, expires_at)
+		VALUES ($1, $2, $3, $4) RETURNING *`
+
+	refreshTokenFindByTokenId = `SELECT * FROM "RefreshToken" WHERE token_id = $1 LIMIT 1`
+
+	refreshTokenMarkUsed = `UPDATE "RefreshToken" SET used_at = NOW()
+		WHERE token_id = $1 AND used_at IS NULL AND revoked_at IS NULL AND expires_at > NOW()`
+
+	refreshTokenRevokeFamily = `UPDATE "RefreshToken" SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL`
+
+	refreshTokenRevokeAllByUser = `UPDATE "RefreshToken" SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL`
+
+	refreshTokenDeleteExpired = `DELETE FROM "RefreshToken" WHERE expires_at < NOW()`
+
+	revokedTokenCreate = `INSERT INTO "RevokedToken" (token_id, user_id, expires_at)
+		VALUES ($1, $2, $3) ON CONFLICT (token_id) DO NOTHING`
+
+	revokedTokenDeleteExpired = `DELETE FROM "RevokedToken" WHERE expires_at < NOW()`
+
+	userTokenRevocationUpsert = `INSERT INTO "UserTokenRevocation" (user_id, revoked_before)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET revoked_before = GREATEST("UserTokenRevocation".revoked_before, EXCLUDED.revoked_before)`
+
+	tokenIsRevoked = `SELECT
+		EXISTS(SELECT 1 FROM "RevokedToken" WHERE token_id = $1)
+		OR EXISTS(SELECT 1 FROM "UserTokenRevocation" WHERE user_id = $2 AND revoked_before > $3)`
+
+	sellerFindById = `
+		SELECT u.*, s.id as seller_id, s.seller_name
+		FROM "Seller" s
+		INNER JOIN "User" u ON u.id = s.user_id
+		WHERE s.id = $1
+		LIMIT 1`
+
+	sellerTransactionCount = `SELECT COUNT(*) FROM "SellTransaction" WHERE seller_id = $1`
+
+	sellerTransactionList = `SELECT
+		st.id,
+		st.collector_id,
+		c.collector_name,
+		st.volume,
+		st.price,
+		st.volume * st.price as total_amount,
+		st.cancelled_at,
+		st.created_at
+	FROM "SellTransaction" st
+	JOIN "Collector" c ON st.collector_id = c.id
+	WHERE st.seller_id = $1
+	ORDER BY st.created_at DESC, st.id DESC
+	LIMIT $2 OFFSET $3`
+
+	sellerSummary = `SELECT
+		COUNT(*) as total_transactions,
+		COALESCE(SUM(volume), 0) as total_volume,
+		COALESCE(SUM(volume * price), 0) as total_earnings,
+		MIN(created_at) as first_transaction_at,
+		MAX(created_at) as last_transaction_at
+	FROM "SellTransaction"
+	WHERE seller_id = $1 AND cancelled_at IS NULL`
+
+	sellerMonthlyAggregate = `SELECT
+		date_trunc('month', created_at) as month,
+		COUNT(*) as total_transactions,
+		SUM(volume) as total_volume,
+		SUM(volume * price) as total_amount
+	FROM "SellTransaction"
+	WHERE seller_id = $1 AND created_at >= $2 AND created_at < $3 AND cancelled_at IS NULL
+	GROUP BY 1
+	ORDER BY 1`
+
+	companyFindById = `
+		SELECT u.*, co.id as company_id, co.company_name
+		FROM "Company" co
+		INNER JOIN "User" u ON u.id = co.user_id
+		WHERE co.id = $1
+		LIMIT 1`
+
+	companyDeliveryCount = `SELECT COUNT(*) FROM "DistributeTransaction"
+		WHERE company_id = $1 AND ($2::BIGINT = 0 OR collector_id = $2)`
+
+	companyDeliveryList = `SELECT
+		dt.id,
+		dt.collector_id,
+		c.collector_name,
+		dt.volume,
+		dt.price,
+		dt.volume * dt.price as total_amount,
+		dt.received_at,
+		dt.cancelled_at,
+		dt.created_at
+	FROM "DistributeTransaction" dt
+	JOIN "Collector" c ON dt.collector_id = c.id
+	WHERE dt.company_id = $1 AND ($2::BIGINT = 0 OR dt.collector_id = $2)
+	ORDER BY dt.created_at DESC, dt.id DESC
+	LIMIT $3 OFFSET $4`
+
+	companyDeliveryFindById = `SELECT
+		dt.id,
+		dt.collector_id,
+		c.collector_name,
+		dt.volume,
+		dt.price,
+		dt.volume * dt.price as total_amount,
+		dt.received_at,
+		dt.cancelled_at,
+		dt.created_at
+	FROM "DistributeTransaction" dt
+	JOIN "Collector" c ON dt.collector_id = c.id
+	WHERE dt.id = $1 AND dt.company_id = $2
+	LIMIT 1`
+
+	companyDeliveryAcknowledge = `UPDATE "DistributeTransaction" SET received_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND company_id = $2 AND received_at IS NULL AND cancelled_at IS NULL`
+
+	companyPeriodAggregate = `SELECT
+		date_trunc($2, created_at) as period,
+		COUNT(*) as total_transactions,
+		SUM(volume) as total_volume,
+		SUM(volume * price) as total_amount
+	FROM "DistributeTransaction"
+	WHERE company_id = $1 AND created_at >= $3 AND created_at < $4 AND cancelled_at IS NULL
+	GROUP BY 1
+	ORDER BY 1`
+
+	// $1 selalu collector_id, filter lain ditambahkan oleh TransactionRepository.ListTransactions
+	transactionHistorySell = `SELECT
+		st.id,
+		'SELL' as transaction_type,
+		st.seller_id as counterparty_id,
+		s.seller_name as counterparty_name,
+		u.email as counterparty_email,
+		st.volume,
+		st.price,
+		st.volume * st.price as total_amount,
+		st.cancelled_at,
+		st.cancel_reason,
+		st.created_at,
+		st.updated_at
+	FROM "SellTransaction" st
+	JOIN "Seller" s ON st.seller_id = s.id
+	JOIN "User" u ON s.user_id = u.id
+	WHERE st.collector_id = $1`
+
+	transactionHistoryBuy = `SELECT
+		dt.id,
+		'BUY' as transaction_type,
+		dt.company_id as counterparty_id,
+		co.company_name as counterparty_name,
+		u.email as counterparty_email,
+		dt.volume,
+		dt.price,
+		dt.volume * dt.price as total_amount,
+		dt.cancelled_at,
+		dt.cancel_reason,
+		dt.created_at,
+		dt.updated_at
+	FROM "DistributeTransaction" dt
+	JOIN "Company" co ON dt.company_id = co.id
+	JOIN "User" u ON co.user_id = u.id
+	WHERE dt.collector_id = $1`
+
+	// baris yang sudah kadaluarsa boleh diklaim ulang, RETURNING kosong berarti key masih dipakai.
+	// Request lain dengan key yang sama akan menunggu di sini sampai transaction pertama selesai.
+	idempotencyKeyClaim = `INSERT INTO "IdempotencyKey" (collector_id, idempotency_key, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (collector_id, idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response_body = NULL, created_at = NOW(), expires_at = EXCLUDED.expires_at
+		WHERE "IdempotencyKey".expires_at <= NOW()
+		RETURNING collector_id`
+
+	idempotencyKeyFind = `SELECT * FROM "IdempotencyKey" WHERE collector_id = $1 AND idempotency_key = $2 LIMIT 1`
+
+	idempotencyKeySaveResponse = `UPDATE "IdempotencyKey" SET response_body = $3
+		WHERE collector_id = $1 AND idempotency_key = $2`
+
+	idempotencyKeyDeleteExpired = `DELETE FROM "IdempotencyKey" WHERE expires_at <= NOW()`
+
+	loginAttemptFind = `SELECT * FROM "LoginAttempt" WHERE attempt_key = $1 AND expires_at > $2 LIMIT 1`
+
+	// hitungan dan lockout dimulai ulang jika record lama sudah expired
+	loginAttemptIncrement = `INSERT INTO "LoginAttempt" (attempt_key, failures, expires_at)
+		VALUES ($1, 1, $3)
+		ON CONFLICT (attempt_key) DO UPDATE
+		SET failures = CASE WHEN "LoginAttempt".expires_at <= $2 THEN 1 ELSE "LoginAttempt".failures + 1 END,
+			locked_until = CASE WHEN "LoginAttempt".expires_at <= $2 THEN NULL ELSE "LoginAttempt".locked_until END,
+			expires_at = EXCLUDED.expires_at
+		RETURNING failures`
+
+	// GREATEST mengabaikan NULL, lockout yang lebih lama tidak diperpendek
+	loginAttemptLock = `UPDATE "LoginAttempt" SET locked_until = GREATEST(locked_until, $2) WHERE attempt_key = $1`
+
+	loginAttemptDelete = `DELETE FROM "LoginAttempt" WHERE attempt_key = $1`
+
+	loginAttemptDeleteExpired = `DELETE FROM "LoginAttempt" WHERE expires_at <= NOW()`
+
+	syncedTransactionClaim = `INSERT INTO "SyncedTransaction" (collector_id, client_id, transaction_type, client_created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (collector_id, client_id) DO NOTHING
+		RETURNING collector_id`
+
+	syncedTransactionFind = `SELECT * FROM "SyncedTransaction" WHERE collector_id = $1 AND client_id = $2 LIMIT 1`
+
+	syncedTransactionSetTransactionId = `UPDATE "SyncedTransaction" SET transaction_id = $3
+		WHERE collector_id = $1 AND client_id = $2`
 )
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type IRefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) Result[*entity.RefreshToken]
+	FindByTokenId(ctx context.Context, tokenId string) Result[*entity.RefreshToken]
+	// MarkUsed mengembalikan false jika token sudah dipakai, dicabut atau kadaluarsa
+	MarkUsed(ctx context.Context, tokenId string) Result[bool]
+	RevokeFamily(ctx context.Context, familyId string) Result[int64]
+	RevokeAllByUser(ctx context.Context, userId int64) Result[int64]
+	DeleteExpired(ctx context.Context) Result[int64]
+}
+
+type RefreshTokenRepository struct {
+	db services.DatabaseService
+}
+
+var _ IRefreshTokenRepository = (*RefreshTokenRepository)(nil)
+
+func NewRefreshTokenRepository(db services.DatabaseService) IRefreshTokenRepository {
+	return &RefreshTokenRepository{db}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) Result[*entity.RefreshToken] {
+	rows := r.db.QueryRowxContext(ctx, refreshTokenCreate,
+		token.UserId,
+		token.TokenId,
+		token.FamilyId,
+		token.ExpiresAt,
+	)
+
+	err := rows.StructScan(token)
+	if err != nil {
+		return handleTokenError[*entity.RefreshToken](ctx, err)
+	}
+
+	return Ok(token)
+}
+
+func (r *RefreshTokenRepository) FindByTokenId(ctx context.Context, tokenId string) Result[*entity.RefreshToken] {
+	rows := r.db.QueryRowxContext(ctx, refreshTokenFindByTokenId, tokenId)
+	token := new(entity.RefreshToken)
+
+	err := rows.StructScan(token)
+	if err != nil {
+		return handleTokenError[*entity.RefreshToken](ctx, err)
+	}
+
+	return Ok(token)
+}
+
+func (r *RefreshTokenRepository) MarkUsed(ctx context.Context, tokenId string) Result[bool] {
+	res, err := r.db.ExecContext(ctx, refreshTokenMarkUsed, tokenId)
+	if err != nil {
+		return handleTokenError[bool](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return Ok(rowsAffected > 0)
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyId string) Result[int64] {
+	res, err := r.db.ExecContext(ctx, refreshTokenRevokeFamily, familyId)
+	if err != nil {
+		return handleTokenError[int64](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return Ok(rowsAffected)
+}
+
+func (r *RefreshTokenRepository) RevokeAllByUser(ctx context.Context, userId int64) Result[int64] {
+	res, err := r.db.ExecContext(ctx, refreshTokenRevokeAllByUser, userId)
+	if err != nil {
+		return handleTokenError[int64](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return Ok(rowsAffected)
+}
+
+func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context) Result[int64] {
+	res, err := r.db.ExecContext(ctx, refreshTokenDeleteExpired)
+	if err != nil {
+		return handleTokenError[int64](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return Ok(rowsAffected)
+}
+
+func handleTokenError[T any](ctx context.Context, err error) Result[T] {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503":
+			return NewError[T]("invalid user_id", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.INVALID_USER_ID)
+		case "23505":
+			return NewError[T]("token already exists", true).WithCause(ENTITY_DUPLICATE).WithMessage(i18n.TOKEN_ALREADY_EXISTS)
+		default:
+			logger.FromContext(ctx).Error("database error", "error", err)
+			return Wrap[T](err, "database error")
+		}
+	} else if errors.Is(err, sql.ErrNoRows) {
+		return NewError[T]("token not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.TOKEN_NOT_FOUND)
+	}
+
+	logger.FromContext(ctx).Error("database error", "error", err)
+
+	return Wrap[T](err, "database error")
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	. "github.com/onsi/gomega"
+)
+
+func TestRefreshTokenRepository_Create_Success(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewRefreshTokenRepository(dbService)
+	ctx := context.Background()
+
+	expiresAt := time.Now().Add(time.Hour)
+	token := &entity.RefreshToken{
+		UserId:    1,
+		TokenId:   "6f1c8a52-6e0b-4d51-9b53-0d1a3c2f6a10",
+		FamilyId:  "a7d9e1b4-0f3c-4a62-8c1e-2b5d7f9e3a41",
+		ExpiresAt: expiresAt,
+	}
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "user_id", "token_id", "family_id", "expires_at", "used_at", "revoked_at", "created_at"}).
+		AddRow(1, 1, token.TokenId, token.FamilyId, expiresAt, nil, nil, now)
+
+	mock.ExpectQuery(`INSERT INTO "RefreshToken"`).
+		WithArgs(int64(1), token.TokenId, token.FamilyId, expiresAt).
+		WillReturnRows(rows)
+
+	result := repo.Create(ctx, token)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().Id).To(Equal(int64(1)))
+	g.Expect(result.Value().IsActive()).To(BeTrue())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestRefreshTokenRepository_FindByTokenId_NotFound(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewRefreshTokenRepository(dbService)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT \* FROM "RefreshToken" WHERE token_id`).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	result := repo.FindByTokenId(ctx, "missing")
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.RootError().Cause()).To(Equal(ENTITY_NOT_FOUND))
+	g.Expect(result.RootError().IsExpected).To(BeTrue())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestRefreshTokenRepository_MarkUsed(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewRefreshTokenRepository(dbService)
+	ctx := context.Background()
+
+	mock.ExpectExec(`UPDATE "RefreshToken" SET used_at`).
+		WithArgs("fresh").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "RefreshToken" SET used_at`).
+		WithArgs("reused").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	fresh := repo.MarkUsed(ctx, "fresh")
+	g.Expect(fresh.IsError()).To(BeFalse())
+	g.Expect(fresh.Value()).To(BeTrue())
+
+	reused := repo.MarkUsed(ctx, "reused")
+	g.Expect(reused.IsError()).To(BeFalse())
+	g.Expect(reused.Value()).To(BeFalse())
+
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestRefreshTokenRepository_RevokeFamily(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewRefreshTokenRepository(dbService)
+	ctx := context.Background()
+
+	mock.ExpectExec(`UPDATE "RefreshToken" SET revoked_at`).
+		WithArgs("family").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	result := repo.RevokeFamily(ctx, "family")
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(Equal(int64(3)))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestRefreshTokenRepository_DeleteExpired(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewRefreshTokenRepository(dbService)
+
+	mock.ExpectExec(`DELETE FROM "RefreshToken" WHERE expires_at < NOW\(\)`).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	result := repo.DeleteExpired(context.Background())
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(Equal(int64(3)))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
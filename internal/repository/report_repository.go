@@ -28,7 +28,7 @@ func NewReportRepository(db services.DatabaseService) IReportRepository {
 func (r ReportRepository) GetSalesReport(ctx context.Context, startDate, endDate string) Result[[]entity.ReportTransaction] {
 	rows, err := r.db.QueryxContext(ctx, reportSalesByDate, startDate, endDate)
 	if err != nil {
-		return handleTransactionError[[]entity.ReportTransaction](err)
+		return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 	}
 	defer rows.Close()
 
@@ -37,13 +37,13 @@ func (r ReportRepository) GetSalesReport(ctx context.Context, startDate, endDate
 		var report entity.ReportTransaction
 		err := rows.StructScan(&report)
 		if err != nil {
-			return handleTransactionError[[]entity.ReportTransaction](err)
+			return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 		}
 		reports = append(reports, report)
 	}
 
 	if err := rows.Err(); err != nil {
-		return handleTransactionError[[]entity.ReportTransaction](err)
+		return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 	}
 
 	return Ok(reports)
@@ -52,7 +52,7 @@ func (r ReportRepository) GetSalesReport(ctx context.Context, startDate, endDate
 func (r ReportRepository) GetPurchasesReport(ctx context.Context, startDate, endDate string) Result[[]entity.ReportTransaction] {
 	rows, err := r.db.QueryxContext(ctx, reportPurchasesByDate, startDate, endDate)
 	if err != nil {
-		return handleTransactionError[[]entity.ReportTransaction](err)
+		return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 	}
 	defer rows.Close()
 
@@ -61,13 +61,13 @@ func (r ReportRepository) GetPurchasesReport(ctx context.Context, startDate, end
 		var report entity.ReportTransaction
 		err := rows.StructScan(&report)
 		if err != nil {
-			return handleTransactionError[[]entity.ReportTransaction](err)
+			return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 		}
 		reports = append(reports, report)
 	}
 
 	if err := rows.Err(); err != nil {
-		return handleTransactionError[[]entity.ReportTransaction](err)
+		return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 	}
 
 	return Ok(reports)
@@ -76,7 +76,7 @@ func (r ReportRepository) GetPurchasesReport(ctx context.Context, startDate, end
 func (r ReportRepository) GetAllSalesReport(ctx context.Context) Result[[]entity.ReportTransaction] {
 	rows, err := r.db.QueryxContext(ctx, reportAllSales)
 	if err != nil {
-		return handleTransactionError[[]entity.ReportTransaction](err)
+		return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 	}
 	defer rows.Close()
 
@@ -85,13 +85,13 @@ func (r ReportRepository) GetAllSalesReport(ctx context.Context) Result[[]entity
 		var report entity.ReportTransaction
 		err := rows.StructScan(&report)
 		if err != nil {
-			return handleTransactionError[[]entity.ReportTransaction](err)
+			return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 		}
 		reports = append(reports, report)
 	}
 
 	if err := rows.Err(); err != nil {
-		return handleTransactionError[[]entity.ReportTransaction](err)
+		return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 	}
 
 	return Ok(reports)
@@ -100,7 +100,7 @@ func (r ReportRepository) GetAllSalesReport(ctx context.Context) Result[[]entity
 func (r ReportRepository) GetAllPurchasesReport(ctx context.Context) Result[[]entity.ReportTransaction] {
 	rows, err := r.db.QueryxContext(ctx, reportAllPurchases)
 	if err != nil {
-		return handleTransactionError[[]entity.ReportTransaction](err)
+		return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 	}
 	defer rows.Close()
 
@@ -109,13 +109,13 @@ func (r ReportRepository) GetAllPurchasesReport(ctx context.Context) Result[[]en
 		var report entity.ReportTransaction
 		err := rows.StructScan(&report)
 		if err != nil {
-			return handleTransactionError[[]entity.ReportTransaction](err)
+			return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 		}
 		reports = append(reports, report)
 	}
 
 	if err := rows.Err(); err != nil {
-		return handleTransactionError[[]entity.ReportTransaction](err)
+		return handleTransactionError[[]entity.ReportTransaction](ctx, err)
 	}
 
 	return Ok(reports)
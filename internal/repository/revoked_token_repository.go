@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+)
+
+type IRevokedTokenRepository interface {
+	Revoke(ctx context.Context, tokenId string, userId int64, expiresAt time.Time) Result[bool]
+	RevokeUser(ctx context.Context, userId int64, before time.Time) Result[bool]
+	IsRevoked(ctx context.Context, tokenId string, userId int64, issuedAt time.Time) Result[bool]
+	DeleteExpired(ctx context.Context) Result[int64]
+}
+
+type RevokedTokenRepository struct {
+	db services.DatabaseService
+}
+
+var _ IRevokedTokenRepository = (*RevokedTokenRepository)(nil)
+
+func NewRevokedTokenRepository(db services.DatabaseService) IRevokedTokenRepository {
+	return &RevokedTokenRepository{db}
+}
+
+func (r *RevokedTokenRepository) Revoke(ctx context.Context, tokenId string, userId int64, expiresAt time.Time) Result[bool] {
+	_, err := r.db.ExecContext(ctx, revokedTokenCreate, tokenId, userId, expiresAt)
+	if err != nil {
+		return handleTokenError[bool](ctx, err)
+	}
+
+	return Ok(true)
+}
+
+func (r *RevokedTokenRepository) RevokeUser(ctx context.Context, userId int64, before time.Time) Result[bool] {
+	_, err := r.db.ExecContext(ctx, userTokenRevocationUpsert, userId, before)
+	if err != nil {
+		return handleTokenError[bool](ctx, err)
+	}
+
+	return Ok(true)
+}
+
+func (r *RevokedTokenRepository) IsRevoked(ctx context.Context, tokenId string, userId int64, issuedAt time.Time) Result[bool] {
+	var revoked bool
+
+	err := r.db.QueryRowxContext(ctx, tokenIsRevoked, tokenId, userId, issuedAt).Scan(&revoked)
+	if err != nil {
+		return handleTokenError[bool](ctx, err)
+	}
+
+	return Ok(revoked)
+}
+
+func (r *RevokedTokenRepository) DeleteExpired(ctx context.Context) Result[int64] {
+	res, err := r.db.ExecContext(ctx, revokedTokenDeleteExpired)
+	if err != nil {
+		return handleTokenError[int64](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+
+	return Ok(rowsAffected)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+)
+
+type ISellerRepository interface {
+	FindById(ctx context.Context, id int64) Result[*entity.UserWithSeller]
+	CountTransactions(ctx context.Context, sellerId int64) Result[int64]
+	ListTransactions(ctx context.Context, sellerId int64, limit, offset int) Result[[]entity.SellerTransactionHistory]
+	GetSummary(ctx context.Context, sellerId int64) Result[*entity.SellerSummary]
+	GetMonthlyAggregates(ctx context.Context, sellerId int64, start, end time.Time) Result[[]entity.MonthlyAggregate]
+}
+
+type SellerRepository struct {
+	db services.DatabaseService
+}
+
+var _ ISellerRepository = (*SellerRepository)(nil)
+
+func NewSellerRepository(db services.DatabaseService) ISellerRepository {
+	return &SellerRepository{db}
+}
+
+func (r *SellerRepository) FindById(ctx context.Context, id int64) Result[*entity.UserWithSeller] {
+	rows := r.db.QueryRowxContext(ctx, sellerFindById, id)
+	seller := new(entity.UserWithSeller)
+
+	err := rows.StructScan(seller)
+	if err != nil {
+		return handleUserError[*entity.UserWithSeller](ctx, err)
+	}
+
+	return Ok(seller)
+}
+
+func (r *SellerRepository) CountTransactions(ctx context.Context, sellerId int64) Result[int64] {
+	var total int64
+
+	err := r.db.QueryRowxContext(ctx, sellerTransactionCount, sellerId).Scan(&total)
+	if err != nil {
+		return handleTransactionError[int64](ctx, err)
+	}
+
+	return Ok(total)
+}
+
+func (r *SellerRepository) ListTransactions(ctx context.Context, sellerId int64, limit, offset int) Result[[]entity.SellerTransactionHistory] {
+	var history []entity.SellerTransactionHistory
+
+	err := r.db.SelectContext(ctx, &history, sellerTransactionList, sellerId, limit, offset)
+	if err != nil {
+		return handleTransactionError[[]entity.SellerTransactionHistory](ctx, err)
+	}
+
+	return Ok(history)
+}
+
+func (r *SellerRepository) GetSummary(ctx context.Context, sellerId int64) Result[*entity.SellerSummary] {
+	summary := new(entity.SellerSummary)
+
+	err := r.db.QueryRowxContext(ctx, sellerSummary, sellerId).StructScan(summary)
+	if err != nil {
+		return handleTransactionError[*entity.SellerSummary](ctx, err)
+	}
+
+	return Ok(summary)
+}
+
+func (r *SellerRepository) GetMonthlyAggregates(ctx context.Context, sellerId int64, start, end time.Time) Result[[]entity.MonthlyAggregate] {
+	var aggregates []entity.MonthlyAggregate
+
+	err := r.db.SelectContext(ctx, &aggregates, sellerMonthlyAggregate, sellerId, start, end)
+	if err != nil {
+		return handleTransactionError[[]entity.MonthlyAggregate](ctx, err)
+	}
+
+	return Ok(aggregates)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+)
+
+func TestSellerRepository_ListTransactions_Success(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewSellerRepository(dbService)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "collector_id", "collector_name", "volume", "price", "total_amount", "created_at"}).
+		AddRow(2, 20, "Test Collector", 10.5, 5000, 52500, now).
+		AddRow(1, 21, "Other Collector", 4, 5100, 20400, now.Add(-time.Hour))
+
+	mock.ExpectQuery(`FROM "SellTransaction" st\s+JOIN "Collector" c`).
+		WithArgs(int64(10), 20, 0).
+		WillReturnRows(rows)
+
+	result := repo.ListTransactions(ctx, 10, 20, 0)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(HaveLen(2))
+	g.Expect(result.Value()[0].CollectorName).To(Equal("Test Collector"))
+	g.Expect(result.Value()[0].TotalAmount).To(Equal(52500.0))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestSellerRepository_GetSummary_NoTransactions(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewSellerRepository(dbService)
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"total_transactions", "total_volume", "total_earnings", "first_transaction_at", "last_transaction_at"}).
+		AddRow(0, 0, 0, nil, nil)
+
+	mock.ExpectQuery(`COALESCE\(SUM\(volume \* price\), 0\) as total_earnings`).
+		WithArgs(int64(10)).
+		WillReturnRows(rows)
+
+	result := repo.GetSummary(ctx, 10)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().TotalTransactions).To(Equal(int64(0)))
+	g.Expect(result.Value().FirstTransactionAt).To(BeNil())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
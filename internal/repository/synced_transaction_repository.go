@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ISyncedTransactionRepository interface {
+	// Claim mengembalikan false jika client_id sudah pernah disinkronkan
+	Claim(ctx context.Context, collectorId int64, clientId string, transactionType string, clientCreatedAt time.Time) Result[bool]
+	Find(ctx context.Context, collectorId int64, clientId string) Result[*entity.SyncedTransaction]
+	SetTransactionId(ctx context.Context, collectorId int64, clientId string, transactionId int64) Result[bool]
+}
+
+type SyncedTransactionRepository struct {
+	db services.DatabaseService
+}
+
+var _ ISyncedTransactionRepository = (*SyncedTransactionRepository)(nil)
+
+func NewSyncedTransactionRepository(db services.DatabaseService) ISyncedTransactionRepository {
+	return &SyncedTransactionRepository{db}
+}
+
+func (r *SyncedTransactionRepository) Claim(ctx context.Context, collectorId int64, clientId string, transactionType string, clientCreatedAt time.Time) Result[bool] {
+	var id int64
+
+	err := r.db.QueryRowxContext(ctx, syncedTransactionClaim, collectorId, clientId, transactionType, clientCreatedAt).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Ok(false)
+	}
+	if err != nil {
+		return handleSyncedTransactionError[bool](ctx, err)
+	}
+
+	return Ok(true)
+}
+
+func (r *SyncedTransactionRepository) Find(ctx context.Context, collectorId int64, clientId string) Result[*entity.SyncedTransaction] {
+	synced := new(entity.SyncedTransaction)
+
+	err := r.db.QueryRowxContext(ctx, syncedTransactionFind, collectorId, clientId).StructScan(synced)
+	if err != nil {
+		return handleSyncedTransactionError[*entity.SyncedTransaction](ctx, err)
+	}
+
+	return Ok(synced)
+}
+
+func (r *SyncedTransactionRepository) SetTransactionId(ctx context.Context, collectorId int64, clientId string, transactionId int64) Result[bool] {
+	res, err := r.db.ExecContext(ctx, syncedTransactionSetTransactionId, collectorId, clientId, transactionId)
+	if err != nil {
+		return handleSyncedTransactionError[bool](ctx, err)
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected <= 0 {
+		return NewError[bool]("synced transaction not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.SYNCED_TRANSACTION_NOT_FOUND)
+	}
+
+	return Ok(true)
+}
+
+func handleSyncedTransactionError[T any](ctx context.Context, err error) Result[T] {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503":
+			return NewError[T]("invalid collector_id", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.INVALID_COLLECTOR_ID)
+		case "22P02":
+			return NewError[T]("invalid client_id", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_CLIENT_ID)
+		default:
+			logger.FromContext(ctx).Error("database error", "error", err)
+			return Wrap[T](err, "database error")
+		}
+	} else if errors.Is(err, sql.ErrNoRows) {
+		return NewError[T]("synced transaction not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.SYNCED_TRANSACTION_NOT_FOUND)
+	}
+
+	logger.FromContext(ctx).Error("database error", "error", err)
+
+	return Wrap[T](err, "database error")
+}
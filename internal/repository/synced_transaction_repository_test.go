@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+)
+
+const testClientId = "9b2f0c4e-6d3a-4f1b-8e7c-2a5d9f1c3b40"
+
+func TestSyncedTransactionRepository_Claim(t *testing.T) {
+	clientCreatedAt := time.Now().Add(-time.Hour)
+
+	cases := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		claimed bool
+	}{
+		{"new client id", sqlmock.NewRows([]string{"collector_id"}).AddRow(7), true},
+		{"already synced", sqlmock.NewRows([]string{"collector_id"}), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockDB, mock, dbService := setupMockDB(t)
+			defer mockDB.Close()
+
+			repo := NewSyncedTransactionRepository(dbService)
+
+			mock.ExpectQuery(`INSERT INTO "SyncedTransaction"`).
+				WithArgs(int64(7), testClientId, "SELL", clientCreatedAt).
+				WillReturnRows(tc.rows)
+
+			result := repo.Claim(context.Background(), 7, testClientId, "SELL", clientCreatedAt)
+
+			g.Expect(result.IsError()).To(BeFalse())
+			g.Expect(result.Value()).To(Equal(tc.claimed))
+			g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	}
+}
+
+func TestSyncedTransactionRepository_Find(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewSyncedTransactionRepository(dbService)
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT \* FROM "SyncedTransaction"`).
+		WithArgs(int64(7), testClientId).
+		WillReturnRows(sqlmock.NewRows([]string{"collector_id", "client_id", "transaction_type", "transaction_id", "client_created_at", "synced_at"}).
+			AddRow(7, testClientId, "BUY", 12, now.Add(-time.Hour), now))
+
+	result := repo.Find(context.Background(), 7, testClientId)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(*result.Value().TransactionId).To(Equal(int64(12)))
+	g.Expect(result.Value().TransactionType).To(Equal("BUY"))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services"
-	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type ITransactionRepository interface {
@@ -18,9 +24,17 @@ type ITransactionRepository interface {
 	UpdateDistributeTransaction(ctx context.Context, id int64, volume float64, price float64) Result[*entity.DistributeTransaction]
 	FindSellTransactionById(ctx context.Context, id int64) Result[*entity.SellTransaction]
 	FindDistributeTransactionById(ctx context.Context, id int64) Result[*entity.DistributeTransaction]
+	LockSellTransactionById(ctx context.Context, id int64) Result[*entity.SellTransaction]
+	LockDistributeTransactionById(ctx context.Context, id int64) Result[*entity.DistributeTransaction]
 	UpdateCollectorVolume(ctx context.Context, collectorId int64, volumeDelta float64) Result[bool]
+	ListTransactions(ctx context.Context, filter entity.TransactionFilter) Result[[]entity.TransactionHistory]
+	CancelSellTransaction(ctx context.Context, id int64, reason string) Result[*entity.SellTransaction]
+	CancelDistributeTransaction(ctx context.Context, id int64, reason string) Result[*entity.DistributeTransaction]
 }
 
+// nama constraint yang dipakai trigger saat total_volume Oil menjadi negatif
+const oilNonNegativeConstraint = "oil_total_volume_non_negative"
+
 type TransactionRepository struct {
 	db services.DatabaseService
 }
@@ -37,11 +51,12 @@ func (r TransactionRepository) CreateSellTransaction(ctx context.Context, tx *en
 		tx.CollectorId,
 		tx.Volume,
 		tx.Price,
+		nullableTime(tx.CreatedAt),
 	)
 
 	err := rows.StructScan(tx)
 	if err != nil {
-		return handleTransactionError[*entity.SellTransaction](err)
+		return handleTransactionError[*entity.SellTransaction](ctx, err)
 	}
 
 	return Ok(tx)
@@ -53,11 +68,12 @@ func (r TransactionRepository) CreateDistributeTransaction(ctx context.Context,
 		tx.CompanyId,
 		tx.Volume,
 		tx.Price,
+		nullableTime(tx.CreatedAt),
 	)
 
 	err := rows.StructScan(tx)
 	if err != nil {
-		return handleTransactionError[*entity.DistributeTransaction](err)
+		return handleTransactionError[*entity.DistributeTransaction](ctx, err)
 	}
 
 	return Ok(tx)
@@ -69,7 +85,7 @@ func (r TransactionRepository) UpdateSellTransaction(ctx context.Context, id int
 
 	err := row.StructScan(tx)
 	if err != nil {
-		return handleTransactionError[*entity.SellTransaction](err)
+		return handleTransactionError[*entity.SellTransaction](ctx, err)
 	}
 
 	return Ok(tx)
@@ -81,7 +97,7 @@ func (r TransactionRepository) UpdateDistributeTransaction(ctx context.Context,
 
 	err := row.StructScan(tx)
 	if err != nil {
-		return handleTransactionError[*entity.DistributeTransaction](err)
+		return handleTransactionError[*entity.DistributeTransaction](ctx, err)
 	}
 
 	return Ok(tx)
@@ -93,7 +109,7 @@ func (r TransactionRepository) FindSellTransactionById(ctx context.Context, id i
 
 	err := row.StructScan(tx)
 	if err != nil {
-		return handleTransactionError[*entity.SellTransaction](err)
+		return handleTransactionError[*entity.SellTransaction](ctx, err)
 	}
 
 	return Ok(tx)
@@ -105,7 +121,57 @@ func (r TransactionRepository) FindDistributeTransactionById(ctx context.Context
 
 	err := row.StructScan(tx)
 	if err != nil {
-		return handleTransactionError[*entity.DistributeTransaction](err)
+		return handleTransactionError[*entity.DistributeTransaction](ctx, err)
+	}
+
+	return Ok(tx)
+}
+
+func (r TransactionRepository) CancelSellTransaction(ctx context.Context, id int64, reason string) Result[*entity.SellTransaction] {
+	tx := &entity.SellTransaction{}
+	row := r.db.QueryRowxContext(ctx, sellTransactionCancel, id, reason)
+
+	err := row.StructScan(tx)
+	if err != nil {
+		return handleTransactionError[*entity.SellTransaction](ctx, err)
+	}
+
+	return Ok(tx)
+}
+
+func (r TransactionRepository) CancelDistributeTransaction(ctx context.Context, id int64, reason string) Result[*entity.DistributeTransaction] {
+	tx := &entity.DistributeTransaction{}
+	row := r.db.QueryRowxContext(ctx, distributeTransactionCancel, id, reason)
+
+	err := row.StructScan(tx)
+	if err != nil {
+		return handleTransactionError[*entity.DistributeTransaction](ctx, err)
+	}
+
+	return Ok(tx)
+}
+
+// LockSellTransactionById sama seperti FindSellTransactionById tetapi mengunci barisnya
+// (SELECT ... FOR UPDATE) sampai database transaction di ctx selesai
+func (r TransactionRepository) LockSellTransactionById(ctx context.Context, id int64) Result[*entity.SellTransaction] {
+	tx := &entity.SellTransaction{}
+	row := r.db.QueryRowxContext(ctx, sellTransactionLockById, id)
+
+	err := row.StructScan(tx)
+	if err != nil {
+		return handleTransactionError[*entity.SellTransaction](ctx, err)
+	}
+
+	return Ok(tx)
+}
+
+func (r TransactionRepository) LockDistributeTransactionById(ctx context.Context, id int64) Result[*entity.DistributeTransaction] {
+	tx := &entity.DistributeTransaction{}
+	row := r.db.QueryRowxContext(ctx, distributeTransactionLockById, id)
+
+	err := row.StructScan(tx)
+	if err != nil {
+		return handleTransactionError[*entity.DistributeTransaction](ctx, err)
 	}
 
 	return Ok(tx)
@@ -114,26 +180,155 @@ func (r TransactionRepository) FindDistributeTransactionById(ctx context.Context
 func (r TransactionRepository) UpdateCollectorVolume(ctx context.Context, collectorId int64, volumeDelta float64) Result[bool] {
 	_, err := r.db.ExecContext(ctx, updateCollectorVolume, collectorId, volumeDelta)
 	if err != nil {
-		return handleTransactionError[bool](err)
+		return handleTransactionError[bool](ctx, err)
 	}
 
 	return Ok(true)
 }
 
-func handleTransactionError[T any](err error) Result[T] {
-	var pgErr pgx.PgError
+func (r TransactionRepository) ListTransactions(ctx context.Context, filter entity.TransactionFilter) Result[[]entity.TransactionHistory] {
+	query, args := buildTransactionListQuery(filter)
+
+	var transactions []entity.TransactionHistory
+	err := r.db.SelectContext(ctx, &transactions, query, args...)
+	if err != nil {
+		return handleTransactionError[[]entity.TransactionHistory](ctx, err)
+	}
+
+	return Ok(transactions)
+}
+
+// buildTransactionListQuery menyusun query listing transaksi. Nama kolom untuk ORDER BY
+// hanya diambil dari SortBy yang sudah divalidasi, semua nilai filter dikirim sebagai parameter.
+func buildTransactionListQuery(filter entity.TransactionFilter) (string, []any) {
+	var source string
+	switch filter.TransactionType {
+	case "SELL":
+		source = transactionHistorySell
+	case "BUY":
+		source = transactionHistoryBuy
+	default:
+		source = transactionHistorySell + "\n\tUNION ALL\n\t" + transactionHistoryBuy
+	}
+
+	args := []any{filter.CollectorId}
+	var conditions []string
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.CounterpartyId > 0 {
+		addCondition("counterparty_id = $%d", filter.CounterpartyId)
+	}
+	if filter.CounterpartyEmail != "" {
+		addCondition("LOWER(counterparty_email) = LOWER($%d)", filter.CounterpartyEmail)
+	}
+	if filter.StartDate != nil {
+		addCondition("created_at >= $%d", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		addCondition("created_at < $%d", *filter.EndDate)
+	}
+	if filter.MinVolume > 0 {
+		addCondition("volume >= $%d", filter.MinVolume)
+	}
+	if filter.MaxVolume > 0 {
+		addCondition("volume <= $%d", filter.MaxVolume)
+	}
+	if filter.MinPrice > 0 {
+		addCondition("price >= $%d", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		addCondition("price <= $%d", filter.MaxPrice)
+	}
+
+	sortBy := filter.SortBy
+	if !sortBy.IsValid() {
+		sortBy = entity.SORT_BY_CREATED_AT
+	}
+
+	// urutan selalu diakhiri (created_at, id, transaction_type) supaya keyset-nya unik,
+	// id saja tidak cukup karena SELL dan BUY berasal dari tabel yang berbeda
+	keys := []string{"created_at", "id", "transaction_type"}
+	if sortBy != entity.SORT_BY_CREATED_AT {
+		keys = append([]string{string(sortBy)}, keys...)
+	}
+
+	direction, comparator := "ASC", ">"
+	if filter.Descending {
+		direction, comparator = "DESC", "<"
+	}
+
+	if c := filter.After; c != nil {
+		var placeholders []string
+		if sortBy != entity.SORT_BY_CREATED_AT {
+			// dikirim sebagai teks supaya dibandingkan sebagai DECIMAL tanpa pembulatan float
+			args = append(args, strconv.FormatFloat(c.Value, 'f', -1, 64))
+			placeholders = append(placeholders, fmt.Sprintf("$%d::numeric", len(args)))
+		}
+		args = append(args, c.CreatedAt, c.Id, c.TransactionType)
+		placeholders = append(placeholders,
+			fmt.Sprintf("$%d::timestamptz", len(args)-2),
+			fmt.Sprintf("$%d::bigint", len(args)-1),
+			fmt.Sprintf("$%d::text", len(args)),
+		)
+
+		conditions = append(conditions, fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(keys, ", "), comparator, strings.Join(placeholders, ", ")))
+	}
+
+	var query strings.Builder
+	query.WriteString("SELECT * FROM (\n\t")
+	query.WriteString(source)
+	query.WriteString("\n) t")
+	if len(conditions) > 0 {
+		query.WriteString("\nWHERE ")
+		query.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	orderBy := make([]string, len(keys))
+	for i, key := range keys {
+		orderBy[i] = key + " " + direction
+	}
+	query.WriteString("\nORDER BY ")
+	query.WriteString(strings.Join(orderBy, ", "))
+
+	args = append(args, filter.Limit)
+	query.WriteString(fmt.Sprintf("\nLIMIT $%d", len(args)))
+
+	return query.String(), args
+}
+
+// nullableTime mengubah waktu kosong menjadi NULL supaya default dari database yang dipakai
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func handleTransactionError[T any](ctx context.Context, err error) Result[T] {
+	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
 		case "23503":
-			return NewError[T]("referenced entity not found", true).WithCause(ENTITY_NOT_FOUND)
+			return NewError[T]("referenced entity not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.REFERENCED_ENTITY_NOT_FOUND)
 		case "23514":
-			return NewError[T]("constraint violation", true).WithCause(INTERNAL_SERVICE_ERROR)
+			if pgErr.ConstraintName == oilNonNegativeConstraint {
+				return NewError[T]("insufficient oil inventory", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INSUFFICIENT_OIL_INVENTORY)
+			}
+			return NewError[T]("constraint violation", true).WithCause(INTERNAL_SERVICE_ERROR).WithMessage(i18n.CONSTRAINT_VIOLATION)
 		default:
-			return NewError[T]("database error: " + err.Error()).WithCause(INTERNAL_SERVICE_ERROR)
+			logger.FromContext(ctx).Error("database error", "error", err)
+			return Wrap[T](err, "database error")
 		}
 	} else if errors.Is(err, sql.ErrNoRows) {
-		return NewError[T]("transaction not found", true).WithCause(ENTITY_NOT_FOUND)
+		return NewError[T]("transaction not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.TRANSACTION_NOT_FOUND)
 	}
 
-	return NewError[T]("database error: " + err.Error()).WithCause(INTERNAL_SERVICE_ERROR)
+	logger.FromContext(ctx).Error("database error", "error", err)
+
+	return Wrap[T](err, "database error")
 }
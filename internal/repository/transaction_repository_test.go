@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/jackc/pgx/v5/pgconn"
+	. "github.com/onsi/gomega"
+)
+
+func TestBuildTransactionListQuery_FirstPage(t *testing.T) {
+	g := NewWithT(t)
+
+	query, args := buildTransactionListQuery(entity.TransactionFilter{
+		CollectorId: 7,
+		SortBy:      entity.SORT_BY_CREATED_AT,
+		Descending:  true,
+		Limit:       21,
+	})
+
+	g.Expect(query).To(ContainSubstring("UNION ALL"))
+	g.Expect(query).NotTo(ContainSubstring("WHERE (created_at"))
+	g.Expect(query).To(HaveSuffix("ORDER BY created_at DESC, id DESC, transaction_type DESC\nLIMIT $2"))
+	g.Expect(args).To(Equal([]any{int64(7), 21}))
+}
+
+func TestBuildTransactionListQuery_FiltersAndCursor(t *testing.T) {
+	g := NewWithT(t)
+
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cursorAt := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	query, args := buildTransactionListQuery(entity.TransactionFilter{
+		CollectorId:     7,
+		TransactionType: "BUY",
+		CounterpartyId:  3,
+		StartDate:       &start,
+		MinVolume:       10,
+		SortBy:          entity.SORT_BY_VOLUME,
+		After: &entity.TransactionCursor{
+			SortBy:          entity.SORT_BY_VOLUME,
+			Value:           12.5,
+			CreatedAt:       cursorAt,
+			Id:              99,
+			TransactionType: "BUY",
+		},
+		Limit: 11,
+	})
+
+	g.Expect(query).NotTo(ContainSubstring("UNION ALL"))
+	g.Expect(query).NotTo(ContainSubstring(`"SellTransaction"`))
+	g.Expect(query).To(ContainSubstring("counterparty_id = $2 AND created_at >= $3 AND volume >= $4"))
+	g.Expect(query).To(ContainSubstring("(volume, created_at, id, transaction_type) > ($5::numeric, $6::timestamptz, $7::bigint, $8::text)"))
+	g.Expect(query).To(HaveSuffix("ORDER BY volume ASC, created_at ASC, id ASC, transaction_type ASC\nLIMIT $9"))
+	g.Expect(args).To(Equal([]any{int64(7), int64(3), start, 10.0, "12.5", cursorAt, int64(99), "BUY", 11}))
+}
+
+func TestTransactionRepository_ListTransactions_Success(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewTransactionRepository(dbService)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "transaction_type", "counterparty_id", "counterparty_name", "counterparty_email", "volume", "price", "total_amount", "created_at", "updated_at"}).
+		AddRow(4, "BUY", 2, "Test Company", "company@example.com", 100, 7000, 700000, now, now).
+		AddRow(4, "SELL", 5, "Test Seller", "seller@example.com", 10, 5000, 50000, now, now)
+
+	mock.ExpectQuery(`SELECT \* FROM \(`).
+		WithArgs(int64(7), 20).
+		WillReturnRows(rows)
+
+	result := repo.ListTransactions(ctx, entity.TransactionFilter{CollectorId: 7, Descending: true, Limit: 20})
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value()).To(HaveLen(2))
+	g.Expect(result.Value()[0].TransactionType).To(Equal("BUY"))
+	g.Expect(result.Value()[1].CounterpartyEmail).To(Equal("seller@example.com"))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestTransactionRepository_CreateDistributeTransaction_InsufficientInventory(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewTransactionRepository(dbService)
+	ctx := context.Background()
+
+	// error yang dikirim trigger update_oil_on_distribute
+	mock.ExpectQuery(`INSERT INTO "DistributeTransaction"`).
+		WithArgs(int64(7), int64(3), 12.5, 7000.0, nil).
+		WillReturnError(&pgconn.PgError{Code: "23514", ConstraintName: oilNonNegativeConstraint})
+
+	result := repo.CreateDistributeTransaction(ctx, &entity.DistributeTransaction{CollectorId: 7, CompanyId: 3, Volume: 12.5, Price: 7000})
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.RootError().Cause()).To(Equal(BAD_REQUEST_ERROR))
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.INSUFFICIENT_OIL_INVENTORY))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestTransactionRepository_CancelSellTransaction_InsufficientInventory(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewTransactionRepository(dbService)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`UPDATE "SellTransaction" SET cancelled_at = NOW\(\)`).
+		WithArgs(int64(4), "wrong seller").
+		WillReturnError(&pgconn.PgError{Code: "23514", ConstraintName: oilNonNegativeConstraint})
+
+	result := repo.CancelSellTransaction(ctx, 4, "wrong seller")
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.RootError().Cause()).To(Equal(BAD_REQUEST_ERROR))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestTransactionRepository_CancelDistributeTransaction_AlreadyCancelled(t *testing.T) {
+	g := NewWithT(t)
+	mockDB, mock, dbService := setupMockDB(t)
+	defer mockDB.Close()
+
+	repo := NewTransactionRepository(dbService)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`UPDATE "DistributeTransaction" SET cancelled_at = NOW\(\)`).
+		WithArgs(int64(4), "duplicate entry").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	result := repo.CancelDistributeTransaction(ctx, 4, "duplicate entry")
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.RootError().Cause()).To(Equal(ENTITY_NOT_FOUND))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
@@ -6,10 +6,12 @@ import (
 	"errors"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services"
 	"github.com/crazydw4rf/oil-bank-backend/internal/types"
-	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type IUserRepository interface {
@@ -18,6 +20,8 @@ type IUserRepository interface {
 	FindByEmailWithSeller(ctx context.Context, email string) Result[*entity.UserWithSeller]
 	FindByEmailWithCollector(ctx context.Context, email string) Result[*entity.UserWithCollector]
 	FindByEmailWithCompany(ctx context.Context, email string) Result[*entity.UserWithCompany]
+	FindByEmailWithProfile(ctx context.Context, email string) Result[*entity.UserWithProfile]
+	FindWithProfile(ctx context.Context, id int64) Result[*entity.UserWithProfile]
 }
 
 type UserRepository struct {
@@ -40,7 +44,7 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) Result[*
 
 	err := rows.StructScan(user)
 	if err != nil {
-		return handleUserError[*entity.User](err)
+		return handleUserError[*entity.User](ctx, err)
 	}
 
 	return Ok(user)
@@ -52,7 +56,7 @@ func (r *UserRepository) Find(ctx context.Context, id int64) Result[*entity.User
 
 	err := rows.StructScan(user)
 	if err != nil {
-		return handleUserError[*entity.User](err)
+		return handleUserError[*entity.User](ctx, err)
 	}
 
 	return Ok(user)
@@ -67,7 +71,7 @@ func (r *UserRepository) Update(ctx context.Context, user *entity.User) Result[*
 
 	err := rows.StructScan(user)
 	if err != nil {
-		return handleUserError[*entity.User](err)
+		return handleUserError[*entity.User](ctx, err)
 	}
 
 	return Ok(user)
@@ -76,12 +80,12 @@ func (r *UserRepository) Update(ctx context.Context, user *entity.User) Result[*
 func (r *UserRepository) Delete(ctx context.Context, id int64) Result[bool] {
 	res, err := r.db.ExecContext(ctx, userDelete, id)
 	if err != nil {
-		return handleUserError[bool](err)
+		return handleUserError[bool](ctx, err)
 	}
 
 	rowsAffected, _ := res.RowsAffected()
 	if rowsAffected <= 0 {
-		return NewError[bool]("can't delete user, user not found", true).WithCause(ENTITY_NOT_FOUND)
+		return NewError[bool]("can't delete user, user not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.USER_NOT_FOUND)
 	}
 
 	return Ok(true)
@@ -93,7 +97,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) Result[*
 
 	err := rows.StructScan(user)
 	if err != nil {
-		return handleUserError[*entity.User](err)
+		return handleUserError[*entity.User](ctx, err)
 	}
 
 	return Ok(user)
@@ -105,7 +109,7 @@ func (r *UserRepository) FindByEmailWithSeller(ctx context.Context, email string
 
 	err := rows.StructScan(user)
 	if err != nil {
-		return handleUserError[*entity.UserWithSeller](err)
+		return handleUserError[*entity.UserWithSeller](ctx, err)
 	}
 
 	return Ok(user)
@@ -117,7 +121,7 @@ func (r *UserRepository) FindByEmailWithCollector(ctx context.Context, email str
 
 	err := rows.StructScan(user)
 	if err != nil {
-		return handleUserError[*entity.UserWithCollector](err)
+		return handleUserError[*entity.UserWithCollector](ctx, err)
 	}
 
 	return Ok(user)
@@ -129,24 +133,51 @@ func (r *UserRepository) FindByEmailWithCompany(ctx context.Context, email strin
 
 	err := rows.StructScan(user)
 	if err != nil {
-		return handleUserError[*entity.UserWithCompany](err)
+		return handleUserError[*entity.UserWithCompany](ctx, err)
 	}
 
 	return Ok(user)
 }
 
-func handleUserError[T any](err error) Result[T] {
-	var pgErr pgx.PgError
+func (r *UserRepository) FindByEmailWithProfile(ctx context.Context, email string) Result[*entity.UserWithProfile] {
+	rows := r.db.QueryRowxContext(ctx, userFindByEmailWithProfile, email)
+	user := new(entity.UserWithProfile)
+
+	err := rows.StructScan(user)
+	if err != nil {
+		return handleUserError[*entity.UserWithProfile](ctx, err)
+	}
+
+	return Ok(user)
+}
+
+func (r *UserRepository) FindWithProfile(ctx context.Context, id int64) Result[*entity.UserWithProfile] {
+	rows := r.db.QueryRowxContext(ctx, userFindWithProfile, id)
+	user := new(entity.UserWithProfile)
+
+	err := rows.StructScan(user)
+	if err != nil {
+		return handleUserError[*entity.UserWithProfile](ctx, err)
+	}
+
+	return Ok(user)
+}
+
+func handleUserError[T any](ctx context.Context, err error) Result[T] {
+	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
 		case "23505":
-			return NewError[T]("user already exists", true).WithCause(ENTITY_DUPLICATE)
+			return NewError[T]("user already exists", true).WithCause(ENTITY_DUPLICATE).WithMessage(i18n.USER_ALREADY_EXISTS)
 		default:
-			return NewError[T]("database error: " + err.Error()).WithCause(INTERNAL_SERVICE_ERROR)
+			logger.FromContext(ctx).Error("database error", "error", err)
+			return Wrap[T](err, "database error")
 		}
 	} else if errors.Is(err, sql.ErrNoRows) {
-		return NewError[T]("user not found", true).WithCause(ENTITY_NOT_FOUND)
+		return NewError[T]("user not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.USER_NOT_FOUND)
 	}
 
-	return NewError[T]("database error: " + err.Error()).WithCause(INTERNAL_SERVICE_ERROR)
+	logger.FromContext(ctx).Error("database error", "error", err)
+
+	return Wrap[T](err, "database error")
 }
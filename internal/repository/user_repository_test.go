@@ -8,17 +8,17 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	. "github.com/onsi/gomega"
 )
 
 // NOTE: This test suite uses sqlmock which works well with sqlx.
-// For better pgx.PgError testing, consider migrating to:
-//   - pgx v5 (github.com/jackc/pgx/v5)
-//   - pgxmock v4 (github.com/pashagolub/pgxmock/v4)
-// This would allow proper mocking of pgx-specific errors without workarounds.
+// Postgres errors are simulated by returning *pgconn.PgError (pgx v5) from the mock,
+// the same type the pgx stdlib driver returns at runtime.
 
 func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, services.DatabaseService) {
 	mockDB, mock, err := sqlmock.New()
@@ -79,17 +79,16 @@ func TestUserRepository_Create_DuplicateEmail(t *testing.T) {
 		UserType:     entity.SELLER,
 	}
 
-	// Simulate a database constraint violation error
-	// Note: With sqlmock, we can't easily create a proper pgx.PgError
-	// The actual pgx error handling is better tested with integration tests
+	// Simulate a unique constraint violation
 	mock.ExpectQuery(`INSERT INTO "User"`).
 		WithArgs("testuser", "duplicate@example.com", "hashedpassword", entity.SELLER).
-		WillReturnError(sql.ErrConnDone) // Generic error for demo
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "User_email_key"})
 
 	result := repo.Create(ctx, user)
 
-	// Verify error handling works (even if not the exact error type)
 	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.RootError().Cause()).To(Equal(ENTITY_DUPLICATE))
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.USER_ALREADY_EXISTS))
 	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
 }
 
@@ -257,7 +256,7 @@ func TestUserRepository_FindByEmail_NotFound(t *testing.T) {
 func TestHandleUserError_NoRows(t *testing.T) {
 	g := NewWithT(t)
 
-	result := handleUserError[*entity.User](sql.ErrNoRows)
+	result := handleUserError[*entity.User](context.Background(), sql.ErrNoRows)
 
 	g.Expect(result.IsError()).To(BeTrue())
 	g.Expect(result.RootError().Cause()).To(Equal(ENTITY_NOT_FOUND))
@@ -270,7 +269,7 @@ func TestHandleUserError_GenericDatabaseError(t *testing.T) {
 
 	// Test with a generic database error
 	genericErr := sql.ErrConnDone
-	result := handleUserError[*entity.User](genericErr)
+	result := handleUserError[*entity.User](context.Background(), genericErr)
 
 	g.Expect(result.IsError()).To(BeTrue())
 	g.Expect(result.RootError().Cause()).To(Equal(INTERNAL_SERVICE_ERROR))
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"go.uber.org/fx"
+)
+
+// CleanupTask menghapus record yang sudah kadaluarsa dan mengembalikan jumlah yang dihapus
+type CleanupTask func(ctx context.Context) Result[int64]
+
+// CleanupJob menjalankan semua task terdaftar secara berkala selama aplikasi berjalan.
+// Setiap instance menjalankan job-nya sendiri, DELETE yang sama dari beberapa instance tetap aman.
+type CleanupJob struct {
+	interval time.Duration
+	log      *slog.Logger
+	names    []string
+	tasks    []CleanupTask
+}
+
+func NewCleanupJob(cfg *config.Config, log *slog.Logger) *CleanupJob {
+	return &CleanupJob{interval: cfg.CLEANUP_INTERVAL, log: log}
+}
+
+// Register menambahkan task, harus dipanggil sebelum job dijalankan
+func (j *CleanupJob) Register(name string, task CleanupTask) {
+	j.names = append(j.names, name)
+	j.tasks = append(j.tasks, task)
+}
+
+// RunOnce menjalankan semua task satu kali, kegagalan satu task tidak menghentikan task lain
+func (j *CleanupJob) RunOnce(ctx context.Context) {
+	for i, task := range j.tasks {
+		result := task(ctx)
+		if result.IsError() {
+			j.log.Error("cleanup failed", "task", j.names[i], "error", result)
+			continue
+		}
+
+		if deleted := result.Value(); deleted > 0 {
+			j.log.Info("expired records deleted", "task", j.names[i], "deleted", deleted)
+		}
+	}
+}
+
+// StartCleanupJob menjalankan job pada OnStart dan menunggunya berhenti pada OnStop
+func StartCleanupJob(lc fx.Lifecycle, job *CleanupJob) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			wg.Go(func() {
+				ticker := time.NewTicker(job.interval)
+				defer ticker.Stop()
+
+				jobCtx := logger.WithContext(ctx, job.log)
+				for {
+					select {
+					case <-ticker.C:
+						job.RunOnce(jobCtx)
+					case <-ctx.Done():
+						return
+					}
+				}
+			})
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	. "github.com/onsi/gomega"
+)
+
+func TestCleanupJob_RunOnceContinuesAfterFailure(t *testing.T) {
+	g := NewWithT(t)
+	job := NewCleanupJob(&config.Config{CLEANUP_INTERVAL: config.DEFAULT_CLEANUP_INTERVAL}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var calls []string
+	job.Register("failing", func(ctx context.Context) Result[int64] {
+		calls = append(calls, "failing")
+		return Wrap[int64](errors.New("connection refused"), "database error")
+	})
+	job.Register("ok", func(ctx context.Context) Result[int64] {
+		calls = append(calls, "ok")
+		return Ok(int64(2))
+	})
+
+	job.RunOnce(context.Background())
+
+	g.Expect(calls).To(Equal([]string{"failing", "ok"}))
+}
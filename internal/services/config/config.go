@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -19,23 +22,170 @@ var (
 )
 
 const (
-	BASE_API_HTTP_PATH            = "/v1"
-	REFRESH_TOKEN_COOKIE_NAME     = "refreshToken"
-	ACCESS_TOKEN_COOKIE_NAME      = "token"
-	ACCESS_TOKEN_HEADER_NAME      = "Authorization"
-	ACCESS_TOKEN_EXPIRATION_TIME  = time.Minute * 15
-	REFRESH_TOKEN_EXPIRATION_TIME = (time.Hour * 24) * 30
+	BASE_API_HTTP_PATH                    = "/v1"
+	ACCESS_TOKEN_HEADER_NAME              = "Authorization"
+	DEFAULT_ACCESS_TOKEN_COOKIE_NAME      = "token"
+	DEFAULT_REFRESH_TOKEN_COOKIE_NAME     = "refreshToken"
+	DEFAULT_ACCESS_TOKEN_EXPIRATION_TIME  = time.Minute * 15
+	DEFAULT_REFRESH_TOKEN_EXPIRATION_TIME = (time.Hour * 24) * 30
+	DEFAULT_REVOCATION_CACHE_TTL          = time.Second * 30
+	IDEMPOTENCY_KEY_HEADER_NAME           = "Idempotency-Key"
+	IDEMPOTENCY_KEY_MAX_LENGTH            = 255
+	DEFAULT_IDEMPOTENCY_KEY_TTL           = time.Hour * 24
+	DEFAULT_LOG_LEVEL                     = "info"
+	DEFAULT_JWT_SIGNING_METHOD            = "HS256"
+	JWKS_PATH                             = "/.well-known/jwks.json"
+	METRICS_PATH                          = "/metrics"
+	DEFAULT_APP_PORT                      = 3000
+	DEFAULT_DB_MAX_CONNS                  = 10
+	DEFAULT_DB_MAX_CONN_LIFETIME          = time.Hour
+	DEFAULT_DB_MAX_CONN_IDLE_TIME         = time.Minute * 30
+	DEFAULT_DB_CONNECT_TIMEOUT            = time.Second * 5
+	DEFAULT_DB_STATEMENT_TIMEOUT          = time.Second * 30
+	HEALTH_CHECK_TIMEOUT                  = time.Second * 2
+	DEFAULT_SHUTDOWN_TIMEOUT              = time.Second * 30
+	DEFAULT_SHUTDOWN_DRAIN_DELAY          = time.Second * 5
+	DEFAULT_LOGIN_ATTEMPT_STORE           = "postgres"
+	DEFAULT_LOGIN_MAX_FAILURES            = 5
+	DEFAULT_LOGIN_IP_MAX_FAILURES         = 20
+	DEFAULT_LOGIN_LOCKOUT_DURATION        = time.Minute
+	DEFAULT_LOGIN_MAX_LOCKOUT_DURATION    = time.Hour
+	DEFAULT_LOGIN_FAILURE_TTL             = time.Hour * 24
+	DEFAULT_CLEANUP_INTERVAL              = time.Hour
+	ADMIN_KEY_HEADER_NAME                 = "X-Admin-Key"
+	// waktu tambahan di atas SHUTDOWN_TIMEOUT untuk menutup pool database
+	DB_CLOSE_TIMEOUT = time.Second * 5
+
+	// CONFIG_FILE_ENV menunjuk file config YAML, TOML atau JSON yang dibaca setelah .env.
+	// Urutan prioritas: environment variable > CONFIG_FILE > .env > default.
+	CONFIG_FILE_ENV = "CONFIG_FILE"
+	// SECRET_FILE_SUFFIX: jika KEY_FILE diisi path, nilai KEY dibaca dari isi file tersebut (Docker secrets)
+	SECRET_FILE_SUFFIX = "_FILE"
 )
 
+// Tag secret menandai field yang disamarkan oleh Dump: "true" untuk seluruh nilai,
+// "url" hanya untuk password di dalam URL.
 type Config struct {
-	APP_PORT                 int    `mapstructure:"APP_PORT"`
+	APP_PORT                 int    `mapstructure:"APP_PORT" validate:"min=1,max=65535"`
 	APP_HOST                 string `mapstructure:"APP_HOST"`
-	DATABASE_URL             string `mapstructure:"DATABASE_URL"`
-	JWT_ACCESS_TOKEN_SECRET  string `mapstructure:"JWT_ACCESS_TOKEN_SECRET"`
-	JWT_REFRESH_TOKEN_SECRET string `mapstructure:"JWT_REFRESH_TOKEN_SECRET"`
+	DATABASE_URL             string `mapstructure:"DATABASE_URL" validate:"required,postgres_url" secret:"url"`
+	JWT_ACCESS_TOKEN_SECRET  string `mapstructure:"JWT_ACCESS_TOKEN_SECRET" validate:"required_if=JWT_SIGNING_METHOD HS256,omitempty,min=32" secret:"true"`
+	JWT_REFRESH_TOKEN_SECRET string `mapstructure:"JWT_REFRESH_TOKEN_SECRET" validate:"required,min=32,nefield=JWT_ACCESS_TOKEN_SECRET" secret:"true"`
+
+	// algoritma access token: HS256 (secret bersama), RS256 atau EdDSA (private key PEM).
+	// Refresh token selalu HS256 dengan JWT_REFRESH_TOKEN_SECRET karena hanya diverifikasi oleh aplikasi ini.
+	JWT_SIGNING_METHOD string `mapstructure:"JWT_SIGNING_METHOD" validate:"oneof=HS256 RS256 EdDSA"`
+	// kid dan private key aktif untuk RS256/EdDSA
+	JWT_SIGNING_KEY_ID   string `mapstructure:"JWT_SIGNING_KEY_ID" validate:"required_unless=JWT_SIGNING_METHOD HS256,omitempty,printascii,excludes=/"`
+	JWT_SIGNING_KEY_PATH string `mapstructure:"JWT_SIGNING_KEY_PATH" validate:"required_unless=JWT_SIGNING_METHOD HS256,omitempty,file"`
+	// folder berisi public key lama <kid>.pem yang masih diterima selama rotasi key
+	JWT_VERIFICATION_KEYS_DIR string `mapstructure:"JWT_VERIFICATION_KEYS_DIR" validate:"omitempty,dir"`
+
+	// masa berlaku token, contoh: 15m, 720h
+	ACCESS_TOKEN_EXPIRATION_TIME  time.Duration `mapstructure:"ACCESS_TOKEN_EXPIRATION_TIME" validate:"gt=0"`
+	REFRESH_TOKEN_EXPIRATION_TIME time.Duration `mapstructure:"REFRESH_TOKEN_EXPIRATION_TIME" validate:"gtfield=ACCESS_TOKEN_EXPIRATION_TIME"`
+	ACCESS_TOKEN_COOKIE_NAME      string        `mapstructure:"ACCESS_TOKEN_COOKIE_NAME" validate:"cookie_name"`
+	REFRESH_TOKEN_COOKIE_NAME     string        `mapstructure:"REFRESH_TOKEN_COOKIE_NAME" validate:"cookie_name,nefield=ACCESS_TOKEN_COOKIE_NAME"`
+
+	// pengaturan pool koneksi database, nilai 0 berarti memakai nilai dari DATABASE_URL atau default pgxpool
+	DB_MAX_CONNS          int32         `mapstructure:"DB_MAX_CONNS" validate:"gte=0"`
+	DB_MIN_CONNS          int32         `mapstructure:"DB_MIN_CONNS" validate:"gte=0"`
+	DB_MAX_CONN_LIFETIME  time.Duration `mapstructure:"DB_MAX_CONN_LIFETIME" validate:"gte=0"`
+	DB_MAX_CONN_IDLE_TIME time.Duration `mapstructure:"DB_MAX_CONN_IDLE_TIME" validate:"gte=0"`
+	DB_CONNECT_TIMEOUT    time.Duration `mapstructure:"DB_CONNECT_TIMEOUT" validate:"gte=0"`
+	// batas waktu setiap query di sisi Postgres (statement_timeout)
+	DB_STATEMENT_TIMEOUT time.Duration `mapstructure:"DB_STATEMENT_TIMEOUT" validate:"gte=0"`
+
+	// lama hasil "token belum dicabut" disimpan di cache, pencabutan dari instance lain
+	// berlaku paling lambat setelah waktu ini. 0 mematikan cache untuk hasil negatif.
+	REVOCATION_CACHE_TTL time.Duration `mapstructure:"REVOCATION_CACHE_TTL" validate:"gte=0,max=5m"`
+
+	// lama Idempotency-Key disimpan, contoh: 24h, 90m
+	IDEMPOTENCY_KEY_TTL time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL" validate:"gt=0"`
+
+	// debug, info, warn atau error
+	LOG_LEVEL string `mapstructure:"LOG_LEVEL" validate:"oneof=debug info warn error"`
+
+	// endpoint /metrics untuk Prometheus, aktif secara default
+	METRICS_ENABLED bool `mapstructure:"METRICS_ENABLED"`
+
+	// batas waktu menunggu request yang sedang berjalan selesai saat aplikasi dimatikan
+	SHUTDOWN_TIMEOUT time.Duration `mapstructure:"SHUTDOWN_TIMEOUT" validate:"gt=0"`
+	// lama menunggu setelah /readyz gagal sebelum server berhenti menerima koneksi, supaya load balancer
+	// sempat berhenti mengirim request. Selama itu request selain probe ditolak 503. Diambil dari
+	// SHUTDOWN_TIMEOUT, sehingga waktu untuk request yang sedang berjalan tinggal selisih keduanya.
+	SHUTDOWN_DRAIN_DELAY time.Duration `mapstructure:"SHUTDOWN_DRAIN_DELAY" validate:"gte=0,ltfield=SHUTDOWN_TIMEOUT"`
+
+	// penyimpanan hitungan login gagal: postgres (dibagi antar instance) atau memory (per instance)
+	LOGIN_ATTEMPT_STORE string `mapstructure:"LOGIN_ATTEMPT_STORE" validate:"oneof=memory postgres"`
+	// jumlah login gagal per akun dan per IP sebelum dikunci
+	LOGIN_MAX_FAILURES    int `mapstructure:"LOGIN_MAX_FAILURES" validate:"gt=0"`
+	LOGIN_IP_MAX_FAILURES int `mapstructure:"LOGIN_IP_MAX_FAILURES" validate:"gtefield=LOGIN_MAX_FAILURES"`
+	// lama lockout pertama, berlipat dua setiap gagal berikutnya sampai LOGIN_MAX_LOCKOUT_DURATION
+	LOGIN_LOCKOUT_DURATION     time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION" validate:"gt=0"`
+	LOGIN_MAX_LOCKOUT_DURATION time.Duration `mapstructure:"LOGIN_MAX_LOCKOUT_DURATION" validate:"gtefield=LOGIN_LOCKOUT_DURATION"`
+	// hitungan login gagal dilupakan jika tidak ada kegagalan baru selama waktu ini
+	LOGIN_FAILURE_TTL time.Duration `mapstructure:"LOGIN_FAILURE_TTL" validate:"gtefield=LOGIN_MAX_LOCKOUT_DURATION"`
+
+	// header berisi IP asli client yang diisi load balancer, contoh: X-Forwarded-For atau X-Real-IP.
+	// Kosong berarti IP diambil dari koneksi, di belakang load balancer semua client terlihat
+	// dengan IP yang sama sehingga batas login gagal per IP berlaku untuk semuanya.
+	PROXY_HEADER string `mapstructure:"PROXY_HEADER"`
+	// IP atau CIDR load balancer yang boleh mengirim PROXY_HEADER, dipisah koma.
+	// Header dari alamat lain diabaikan supaya client tidak bisa memalsukan IP-nya.
+	TRUSTED_PROXIES []string `mapstructure:"TRUSTED_PROXIES" validate:"required_with=PROXY_HEADER,dive,ip|cidr"`
+
+	// jarak antar penghapusan token, idempotency key dan hitungan login yang sudah kadaluarsa
+	CLEANUP_INTERVAL time.Duration `mapstructure:"CLEANUP_INTERVAL" validate:"gt=0"`
+
+	// key untuk endpoint admin lewat header X-Admin-Key, endpoint admin tidak dipasang jika kosong
+	ADMIN_API_KEY string `mapstructure:"ADMIN_API_KEY" validate:"omitempty,min=32" secret:"true"`
 }
 
+var defaults = map[string]any{
+	"APP_PORT":                      DEFAULT_APP_PORT,
+	"ACCESS_TOKEN_EXPIRATION_TIME":  DEFAULT_ACCESS_TOKEN_EXPIRATION_TIME,
+	"REFRESH_TOKEN_EXPIRATION_TIME": DEFAULT_REFRESH_TOKEN_EXPIRATION_TIME,
+	"ACCESS_TOKEN_COOKIE_NAME":      DEFAULT_ACCESS_TOKEN_COOKIE_NAME,
+	"REFRESH_TOKEN_COOKIE_NAME":     DEFAULT_REFRESH_TOKEN_COOKIE_NAME,
+	"DB_MAX_CONNS":                  DEFAULT_DB_MAX_CONNS,
+	"DB_MAX_CONN_LIFETIME":          DEFAULT_DB_MAX_CONN_LIFETIME,
+	"DB_MAX_CONN_IDLE_TIME":         DEFAULT_DB_MAX_CONN_IDLE_TIME,
+	"DB_CONNECT_TIMEOUT":            DEFAULT_DB_CONNECT_TIMEOUT,
+	"DB_STATEMENT_TIMEOUT":          DEFAULT_DB_STATEMENT_TIMEOUT,
+	"IDEMPOTENCY_KEY_TTL":           DEFAULT_IDEMPOTENCY_KEY_TTL,
+	"REVOCATION_CACHE_TTL":          DEFAULT_REVOCATION_CACHE_TTL,
+	"LOG_LEVEL":                     DEFAULT_LOG_LEVEL,
+	"JWT_SIGNING_METHOD":            DEFAULT_JWT_SIGNING_METHOD,
+	"METRICS_ENABLED":               true,
+	"SHUTDOWN_TIMEOUT":              DEFAULT_SHUTDOWN_TIMEOUT,
+	"SHUTDOWN_DRAIN_DELAY":          DEFAULT_SHUTDOWN_DRAIN_DELAY,
+	"LOGIN_ATTEMPT_STORE":           DEFAULT_LOGIN_ATTEMPT_STORE,
+	"LOGIN_MAX_FAILURES":            DEFAULT_LOGIN_MAX_FAILURES,
+	"LOGIN_IP_MAX_FAILURES":         DEFAULT_LOGIN_IP_MAX_FAILURES,
+	"LOGIN_LOCKOUT_DURATION":        DEFAULT_LOGIN_LOCKOUT_DURATION,
+	"LOGIN_MAX_LOCKOUT_DURATION":    DEFAULT_LOGIN_MAX_LOCKOUT_DURATION,
+	"LOGIN_FAILURE_TTL":             DEFAULT_LOGIN_FAILURE_TTL,
+	"CLEANUP_INTERVAL":              DEFAULT_CLEANUP_INTERVAL,
+}
+
+// InitConfig membaca config lalu memvalidasinya. Semua key yang tidak valid
+// dilaporkan sekaligus supaya aplikasi gagal start dengan pesan yang jelas.
 func InitConfig() (*Config, error) {
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Load membaca config dari semua sumber tanpa validasi, dipakai juga oleh perintah dump
+func Load() (*Config, error) {
 	cfg := new(Config)
 	v := viper.New()
 
@@ -44,39 +194,102 @@ func InitConfig() (*Config, error) {
 	v.SetConfigName(".env")
 	v.AutomaticEnv()
 
-	bindEnvStruct(v, cfg)
+	keys := bindEnvStruct(v, cfg)
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
 
-	err := v.ReadInConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning reading config file: %#v\n", err)
+	// .env boleh tidak ada jika semua config diberikan lewat environment variable
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to read .env: %w", err)
+		}
 	}
 
-	// TODO: struct validation?
-	err = v.Unmarshal(cfg)
-	if err != nil {
+	if path := os.Getenv(CONFIG_FILE_ENV); path != "" {
+		if err := mergeConfigFile(v, path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := readSecretFiles(v, keys); err != nil {
 		return nil, err
 	}
 
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
 	return cfg, nil
 }
 
-func bindEnvStruct(v *viper.Viper, s any) {
+func mergeConfigFile(v *viper.Viper, path string) error {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	switch ext {
+	case "yaml", "yml", "toml", "json":
+	default:
+		return fmt.Errorf("unsupported config file %q: must be .yaml, .yml, .toml or .json", path)
+	}
+
+	v.SetConfigFile(path)
+	v.SetConfigType(ext)
+	if err := v.MergeInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// readSecretFiles mengisi KEY dari file yang ditunjuk KEY_FILE.
+// Semua kesalahan dikumpulkan supaya dilaporkan sekaligus.
+func readSecretFiles(v *viper.Viper, keys []string) error {
+	var errs []error
+	for _, key := range keys {
+		path := os.Getenv(key + SECRET_FILE_SUFFIX)
+		if path == "" {
+			continue
+		}
+
+		if os.Getenv(key) != "" {
+			errs = append(errs, fmt.Errorf("%s and %s%s are both set", key, key, SECRET_FILE_SUFFIX))
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s%s: %w", key, SECRET_FILE_SUFFIX, err))
+			continue
+		}
+
+		v.Set(key, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// bindEnvStruct mengembalikan daftar key yang di-bind sesuai urutan field
+func bindEnvStruct(v *viper.Viper, s any) []string {
 	val := reflect.ValueOf(s)
 	if val.Kind() == reflect.Pointer {
 		val = val.Elem()
 	}
 
 	if val.Kind() != reflect.Struct {
-		return
+		return nil
 	}
 
 	typ := val.Type()
 
+	keys := make([]string, 0, typ.NumField())
 	for i := range typ.NumField() {
 		field := typ.Field(i)
 		tagValue := field.Tag.Get("mapstructure")
 		if tagValue != "" {
 			v.BindEnv(field.Name, tagValue)
+			keys = append(keys, tagValue)
 		}
 	}
+
+	return keys
 }
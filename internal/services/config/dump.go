@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const REDACTED = "******"
+
+// Dump menulis config dalam format .env dengan secret disamarkan,
+// dipakai untuk memeriksa config yang benar-benar terbaca tanpa membocorkan secret
+func (c *Config) Dump(w io.Writer) error {
+	val := reflect.ValueOf(c).Elem()
+	typ := val.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+
+		value := fmt.Sprint(val.Field(i).Interface())
+		if list, ok := val.Field(i).Interface().([]string); ok {
+			value = strings.Join(list, ",")
+		}
+		if value != "" {
+			value = redact(field.Tag.Get("secret"), value)
+		}
+
+		if _, err := fmt.Fprintf(w, "%s=%s\n", key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func redact(mode string, value string) string {
+	switch mode {
+	case "true":
+		return REDACTED
+	case "url":
+		u, err := url.Parse(value)
+		if err != nil {
+			return REDACTED
+		}
+		// password juga bisa diberikan lewat query, contoh: ?password=...
+		if q := u.Query(); q.Has("password") {
+			q.Set("password", REDACTED)
+			u.RawQuery = q.Encode()
+		}
+		return u.Redacted()
+	default:
+		return value
+	}
+}
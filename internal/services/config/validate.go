@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	playground "github.com/go-playground/validator/v10"
+)
+
+var validate = newValidate()
+
+func newValidate() *playground.Validate {
+	v := playground.New()
+
+	_ = v.RegisterValidation("postgres_url", func(fl playground.FieldLevel) bool {
+		u, err := url.Parse(fl.Field().String())
+		return err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") && u.Host != ""
+	})
+
+	_ = v.RegisterValidation("cookie_name", func(fl playground.FieldLevel) bool {
+		return isCookieName(fl.Field().String())
+	})
+
+	return v
+}
+
+// isCookieName mengikuti aturan token pada RFC 6265: ASCII yang bisa dicetak tanpa separator
+func isCookieName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		if r <= ' ' || r >= 0x7f || strings.ContainsRune(`()<>@,;:\"/[]?={}`, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// InvalidConfigError berisi semua key yang tidak valid, nilai key tidak ikut ditampilkan
+// karena bisa berisi secret
+type InvalidConfigError struct {
+	Keys []InvalidKey
+}
+
+type InvalidKey struct {
+	Key    string
+	Reason string
+}
+
+func (e *InvalidConfigError) Error() string {
+	b := strings.Builder{}
+	b.WriteString("invalid config:")
+	for _, k := range e.Keys {
+		fmt.Fprintf(&b, "\n  - %s: %s", k.Key, k.Reason)
+	}
+
+	return b.String()
+}
+
+// Validate menjalankan aturan dari tag validate, hasilnya nil atau *InvalidConfigError
+func (c *Config) Validate() error {
+	err := validate.Struct(c)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs playground.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	invalid := &InvalidConfigError{Keys: make([]InvalidKey, len(fieldErrs))}
+	for i, fe := range fieldErrs {
+		invalid.Keys[i] = InvalidKey{Key: fe.Field(), Reason: reason(fe)}
+	}
+
+	return invalid
+}
+
+func reason(fe playground.FieldError) string {
+	isString := fe.Kind() == reflect.String
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_if":
+		return fmt.Sprintf("is required when %s", strings.Replace(fe.Param(), " ", " is ", 1))
+	case "required_unless":
+		return fmt.Sprintf("is required unless %s", strings.Replace(fe.Param(), " ", " is ", 1))
+	case "file":
+		return "must be an existing file"
+	case "dir":
+		return "must be an existing directory"
+	case "printascii", "excludes":
+		return "must be printable ASCII without '/'"
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "gtfield":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gtefield":
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "ltfield":
+		return fmt.Sprintf("must be less than %s", fe.Param())
+	case "nefield":
+		return fmt.Sprintf("must be different from %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "postgres_url":
+		return "must be a valid postgres:// or postgresql:// URL with a host"
+	case "ip|cidr":
+		return "must be an IP address or CIDR range"
+	case "required_with":
+		return fmt.Sprintf("is required when %s is set", fe.Param())
+	case "cookie_name":
+		return "must be a valid cookie name (printable ASCII without spaces or separators)"
+	default:
+		return fmt.Sprintf("failed %q validation", fe.Tag())
+	}
+}
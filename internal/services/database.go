@@ -1,24 +1,125 @@
 package services
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"strconv"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
-	"github.com/gofiber/fiber/v2/log"
-	_ "github.com/jackc/pgx/stdlib"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 )
 
+// DatabaseService membungkus *sqlx.DB. Method query di bawah ini otomatis memakai
+// *sqlx.Tx yang sedang berjalan di ctx (lihat UnitOfWork), sehingga repository
+// bisa dipakai di dalam maupun di luar database transaction tanpa perubahan.
+//
+// Koneksi dikelola oleh pgxpool (pgx v5), *sqlx.DB hanya lapisan database/sql di atas pool tersebut.
 type DatabaseService struct {
 	*sqlx.DB
+	Pool *pgxpool.Pool
+}
+
+// executor adalah method yang dimiliki *sqlx.DB dan *sqlx.Tx
+type executor interface {
+	sqlx.ExtContext
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
 }
 
 func NewDatabaseService(cfg *config.Config) (DatabaseService, error) {
-	db, err := sqlx.Connect("pgx", cfg.DATABASE_URL)
+	poolConfig, err := newPoolConfig(cfg)
+	if err != nil {
+		return DatabaseService{}, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
-		log.Errorf("%#v\n", err)
+		return DatabaseService{}, fmt.Errorf("failed to create database pool: %w", err)
+	}
+
+	db := sqlx.NewDb(stdlib.OpenDBFromPool(pool), "pgx")
+
+	// pgxpool tidak membuka koneksi saat dibuat, ping supaya aplikasi gagal start jika database tidak bisa dihubungi.
+	// Lama percobaan koneksi sudah dibatasi oleh ConnectTimeout.
+	if err := db.PingContext(context.Background()); err != nil {
+		pool.Close()
 		return DatabaseService{}, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	return DatabaseService{db}, nil
+	return DatabaseService{DB: db, Pool: pool}, nil
+}
+
+// newPoolConfig membaca DATABASE_URL lalu menimpa pengaturan pool dengan nilai dari config.
+// Nilai nol berarti memakai pengaturan dari DATABASE_URL atau default pgxpool.
+func newPoolConfig(cfg *config.Config) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.DATABASE_URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DATABASE_URL: %w", err)
+	}
+
+	if cfg.DB_MAX_CONNS > 0 {
+		poolConfig.MaxConns = cfg.DB_MAX_CONNS
+	}
+	if cfg.DB_MIN_CONNS > 0 {
+		poolConfig.MinConns = cfg.DB_MIN_CONNS
+	}
+	if cfg.DB_MAX_CONN_LIFETIME > 0 {
+		poolConfig.MaxConnLifetime = cfg.DB_MAX_CONN_LIFETIME
+	}
+	if cfg.DB_MAX_CONN_IDLE_TIME > 0 {
+		poolConfig.MaxConnIdleTime = cfg.DB_MAX_CONN_IDLE_TIME
+	}
+	if cfg.DB_CONNECT_TIMEOUT > 0 {
+		poolConfig.ConnConfig.ConnectTimeout = cfg.DB_CONNECT_TIMEOUT
+	}
+	if cfg.DB_STATEMENT_TIMEOUT > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.DB_STATEMENT_TIMEOUT.Milliseconds(), 10)
+	}
+
+	return poolConfig, nil
+}
+
+// Close menutup *sql.DB lalu pool di bawahnya, menutup *sql.DB saja tidak menutup pool
+func (s DatabaseService) Close() error {
+	err := s.DB.Close()
+	if s.Pool != nil {
+		s.Pool.Close()
+	}
+
+	return err
+}
+
+func (s DatabaseService) conn(ctx context.Context) executor {
+	if tx := TxFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return s.DB
+}
+
+func (s DatabaseService) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return s.conn(ctx).ExecContext(ctx, query, args...)
+}
+
+func (s DatabaseService) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return s.conn(ctx).QueryContext(ctx, query, args...)
+}
+
+func (s DatabaseService) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	return s.conn(ctx).QueryxContext(ctx, query, args...)
+}
+
+func (s DatabaseService) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	return s.conn(ctx).QueryRowxContext(ctx, query, args...)
+}
+
+func (s DatabaseService) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return s.conn(ctx).SelectContext(ctx, dest, query, args...)
+}
+
+func (s DatabaseService) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	return s.conn(ctx).GetContext(ctx, dest, query, args...)
 }
@@ -1,12 +1,20 @@
 package services
 
 import (
+	"log/slog"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/constants"
+	"github.com/crazydw4rf/oil-bank-backend/internal/delivery/http/response"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/gofiber/fiber/v2/utils"
 )
 
-func NewFiberService() *fiber.App {
+func NewFiberService(cfg *config.Config, log *slog.Logger) *fiber.App {
 	isDevMode := config.APP_ENV == "development"
 	app := fiber.New(fiber.Config{
 		EnablePrintRoutes:     isDevMode,
@@ -14,7 +22,33 @@ func NewFiberService() *fiber.App {
 		DisableStartupMessage: !isDevMode,
 		JSONEncoder:           json.Marshal,
 		JSONDecoder:           json.Unmarshal,
+		ErrorHandler:          response.ErrorHandler,
+		// c.IP() hanya membaca ProxyHeader dari TRUSTED_PROXIES dan mengambil IP valid pertama
+		ProxyHeader:             cfg.PROXY_HEADER,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          cfg.TRUSTED_PROXIES,
+		EnableIPValidation:      true,
 	})
 
+	if cfg.PROXY_HEADER == "" || len(cfg.TRUSTED_PROXIES) == 0 {
+		log.Warn("PROXY_HEADER or TRUSTED_PROXIES is not set, client IP is taken from the connection. "+
+			"Behind a load balancer every client shares its IP and the per-IP login limit locks out all of them",
+			"proxy_header", cfg.PROXY_HEADER, "trusted_proxies", cfg.TRUSTED_PROXIES)
+	}
+
+	// request id dari header X-Request-ID dipakai ulang, jika kosong dibuat baru.
+	// Nilainya dikirim sebagai trace_id pada error dan ikut tercatat di log.
+	app.Use(requestid.New(requestid.Config{
+		Generator:  utils.UUIDv4,
+		ContextKey: constants.RequestIdKey,
+	}))
+
+	app.Use(logger.Middleware(log))
+
+	// panic di handler diubah menjadi error 500 oleh ErrorHandler
+	app.Use(recover.New(recover.Config{
+		EnableStackTrace: isDevMode,
+	}))
+
 	return app
 }
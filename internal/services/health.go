@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/db/migrations"
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+)
+
+// HealthService dipakai oleh endpoint /healthz dan /readyz
+type HealthService struct {
+	db              DatabaseService
+	drain           *DrainState
+	expectedVersion uint
+}
+
+func NewHealthService(db DatabaseService, drain *DrainState) HealthService {
+	return HealthService{db, drain, migrations.LatestVersion()}
+}
+
+// Liveness hanya menandakan proses masih berjalan. Database sengaja tidak diperiksa
+// supaya instance tidak di-restart terus menerus saat database sedang down.
+func (s HealthService) Liveness() dto.HealthResponse {
+	return dto.HealthResponse{Status: dto.HEALTH_UP, Build: buildInfo()}
+}
+
+// Readiness memeriksa koneksi database dan versi migrasi, setiap pemeriksaan
+// dibatasi config.HEALTH_CHECK_TIMEOUT. Saat aplikasi sedang dimatikan database tidak
+// diperiksa lagi dan status langsung DOWN.
+func (s HealthService) Readiness(ctx context.Context) dto.HealthResponse {
+	if s.drain.IsDraining() {
+		return dto.HealthResponse{
+			Status: dto.HEALTH_DOWN,
+			Build:  buildInfo(),
+			Checks: map[string]dto.HealthCheck{
+				"shutdown": {Status: dto.HEALTH_DOWN, Error: "service is shutting down"},
+			},
+		}
+	}
+
+	resp := dto.HealthResponse{
+		Status: dto.HEALTH_UP,
+		Build:  buildInfo(),
+		Checks: map[string]dto.HealthCheck{
+			"database":   s.check(ctx, s.pingDatabase),
+			"migrations": s.check(ctx, s.checkMigrations),
+		},
+	}
+
+	for _, check := range resp.Checks {
+		if check.Status != dto.HEALTH_UP {
+			resp.Status = dto.HEALTH_DOWN
+		}
+	}
+
+	return resp
+}
+
+func (s HealthService) check(ctx context.Context, fn func(ctx context.Context) (any, error)) dto.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, config.HEALTH_CHECK_TIMEOUT)
+	defer cancel()
+
+	start := time.Now()
+	details, err := fn(ctx)
+	check := dto.HealthCheck{Status: dto.HEALTH_UP, Latency: time.Since(start).String(), Details: details}
+	if err != nil {
+		check.Status = dto.HEALTH_DOWN
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+func (s HealthService) pingDatabase(ctx context.Context) (any, error) {
+	return nil, s.db.PingContext(ctx)
+}
+
+func (s HealthService) checkMigrations(ctx context.Context) (any, error) {
+	details := dto.MigrationDetails{Expected: s.expectedVersion}
+
+	// tabel schema_migrations dibuat oleh golang-migrate (lihat db/migrate.go)
+	err := s.db.QueryRowxContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&details.Current, &details.Dirty)
+	if err != nil {
+		return details, fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	switch {
+	case details.Dirty:
+		return details, errors.New("database schema is dirty")
+	case details.Current != details.Expected:
+		return details, fmt.Errorf("database schema version %d does not match expected version %d", details.Current, details.Expected)
+	}
+
+	return details, nil
+}
+
+func buildInfo() dto.BuildInfo {
+	return dto.BuildInfo{Version: config.APP_VERSION, Env: config.APP_ENV}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/jmoiron/sqlx"
+	. "github.com/onsi/gomega"
+)
+
+func setupHealthService(t *testing.T, expectedVersion uint) (HealthService, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+
+	db := DatabaseService{DB: sqlx.NewDb(mockDB, "sqlmock")}
+	return HealthService{db, NewDrainState(), expectedVersion}, mock
+}
+
+func TestReadiness_Up(t *testing.T) {
+	g := NewWithT(t)
+	health, mock := setupHealthService(t, 20251124090000)
+
+	mock.ExpectPing()
+	mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(20251124090000, false))
+
+	resp := health.Readiness(context.Background())
+
+	g.Expect(resp.Status).To(Equal(dto.HEALTH_UP))
+	g.Expect(resp.Checks["database"].Status).To(Equal(dto.HEALTH_UP))
+	g.Expect(resp.Checks["migrations"].Details).To(Equal(dto.MigrationDetails{Current: 20251124090000, Expected: 20251124090000}))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestReadiness_Down(t *testing.T) {
+	cases := []struct {
+		name     string
+		pingErr  error
+		version  uint
+		dirty    bool
+		failing  string
+		errorMsg string
+	}{
+		{"database unreachable", errors.New("connection refused"), 20251124090000, false, "database", "connection refused"},
+		{"outdated schema", nil, 20251122090000, false, "migrations", "does not match expected version"},
+		{"dirty schema", nil, 20251124090000, true, "migrations", "dirty"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			health, mock := setupHealthService(t, 20251124090000)
+
+			mock.ExpectPing().WillReturnError(tc.pingErr)
+			mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+				WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(tc.version, tc.dirty))
+
+			resp := health.Readiness(context.Background())
+
+			g.Expect(resp.Status).To(Equal(dto.HEALTH_DOWN))
+			g.Expect(resp.Checks[tc.failing].Status).To(Equal(dto.HEALTH_DOWN))
+			g.Expect(resp.Checks[tc.failing].Error).To(ContainSubstring(tc.errorMsg))
+		})
+	}
+}
+
+func TestReadiness_Draining(t *testing.T) {
+	g := NewWithT(t)
+	health, mock := setupHealthService(t, 20251124090000)
+
+	health.drain.StartDraining()
+	resp := health.Readiness(context.Background())
+
+	g.Expect(resp.Status).To(Equal(dto.HEALTH_DOWN))
+	g.Expect(resp.Checks).To(HaveKey("shutdown"))
+	g.Expect(health.Liveness().Status).To(Equal(dto.HEALTH_UP))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
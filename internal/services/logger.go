@@ -0,0 +1,29 @@
+package services
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+)
+
+// NewLogger membuat logger utama aplikasi. Output berupa JSON kecuali di mode development.
+// Logger ini juga dijadikan slog.Default() sehingga package log ikut tercatat dengan format yang sama.
+func NewLogger(cfg *config.Config) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LOG_LEVEL)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler = slog.NewJSONHandler(os.Stdout, opts)
+	if config.APP_ENV == "development" {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	}
+
+	logger := slog.New(handler).With(slog.String("app_version", config.APP_VERSION))
+	slog.SetDefault(logger)
+
+	return logger
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/metrics"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// SetupMetrics memasang middleware metrics dan endpoint /metrics jika METRICS_ENABLED aktif.
+// Harus di-invoke sebelum router lain supaya middleware berlaku untuk semua route.
+func SetupMetrics(app *fiber.App, m *metrics.Metrics, db DatabaseService, cfg *config.Config) {
+	if !cfg.METRICS_ENABLED {
+		return
+	}
+
+	m.Registry.MustRegister(collectors.NewDBStatsCollector(db.DB.DB, metrics.NAMESPACE))
+
+	app.Use(m.Middleware())
+	app.Get(config.METRICS_PATH, m.Handler())
+}
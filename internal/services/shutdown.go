@@ -0,0 +1,21 @@
+package services
+
+import "sync/atomic"
+
+// DrainState menandai aplikasi sedang dimatikan. Selama draining /readyz mengembalikan 503
+// dan request baru ditolak, sedangkan request yang sedang berjalan tetap diselesaikan.
+type DrainState struct {
+	draining atomic.Bool
+}
+
+func NewDrainState() *DrainState {
+	return new(DrainState)
+}
+
+func (d *DrainState) StartDraining() {
+	d.draining.Store(true)
+}
+
+func (d *DrainState) IsDraining() bool {
+	return d.draining.Load()
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/jmoiron/sqlx"
+)
+
+type txContextKey struct{}
+
+// errResultRollback menandai fn mengembalikan Result error sehingga transaction di-rollback
+var errResultRollback = errors.New("rollback: result contains error")
+
+// UnitOfWork menjalankan beberapa pemanggilan repository di dalam satu database transaction.
+// *sqlx.Tx dibawa lewat context, jadi repository cukup meneruskan ctx yang diterimanya.
+type UnitOfWork interface {
+	// Do menjalankan fn di dalam transaction. Commit jika fn mengembalikan nil,
+	// rollback jika fn mengembalikan error atau panic. Jika ctx sudah berada di dalam
+	// transaction, fn ikut transaction tersebut dan opts diabaikan.
+	Do(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error
+}
+
+type unitOfWork struct {
+	db DatabaseService
+}
+
+var _ UnitOfWork = (*unitOfWork)(nil)
+
+func NewUnitOfWork(db DatabaseService) UnitOfWork {
+	return &unitOfWork{db}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) (err error) {
+	if TxFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(ContextWithTx(ctx, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to rollback transaction: %w", rbErr))
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// InTransaction menjalankan fn lewat uow.Do dan melakukan rollback jika Result dari fn berisi error.
+// Result error dari fn dikembalikan apa adanya supaya ExpectedError dan cause-nya tetap utuh.
+func InTransaction[T any](ctx context.Context, uow UnitOfWork, opts *sql.TxOptions, fn func(ctx context.Context) Result[T]) Result[T] {
+	var result Result[T]
+
+	err := uow.Do(ctx, opts, func(ctx context.Context) error {
+		result = fn(ctx)
+		if result.IsError() {
+			return errResultRollback
+		}
+		return nil
+	})
+	if result.IsError() {
+		return result
+	}
+	if err != nil {
+		return Wrap[T](err, "database transaction failed")
+	}
+
+	return result
+}
+
+func ContextWithTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext mengembalikan nil jika ctx tidak berada di dalam transaction
+func TxFromContext(ctx context.Context) *sqlx.Tx {
+	tx, _ := ctx.Value(txContextKey{}).(*sqlx.Tx)
+	return tx
+}
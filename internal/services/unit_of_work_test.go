@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/jmoiron/sqlx"
+	. "github.com/onsi/gomega"
+)
+
+func setupUnitOfWork(t *testing.T) (DatabaseService, UnitOfWork, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+
+	db := DatabaseService{DB: sqlx.NewDb(mockDB, "sqlmock")}
+	return db, NewUnitOfWork(db), mock
+}
+
+func TestInTransaction_CommitsOnSuccess(t *testing.T) {
+	g := NewWithT(t)
+	db, uow, mock := setupUnitOfWork(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "Oil"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result := InTransaction(context.Background(), uow, nil, func(ctx context.Context) Result[bool] {
+		g.Expect(TxFromContext(ctx)).NotTo(BeNil())
+
+		if _, err := db.ExecContext(ctx, `UPDATE "Oil"`); err != nil {
+			return NewError[bool](err.Error())
+		}
+		return Ok(true)
+	})
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestInTransaction_RollsBackOnResultError(t *testing.T) {
+	g := NewWithT(t)
+	db, uow, mock := setupUnitOfWork(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "Oil"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	result := InTransaction(context.Background(), uow, &sql.TxOptions{Isolation: sql.LevelSerializable}, func(ctx context.Context) Result[bool] {
+		if _, err := db.ExecContext(ctx, `UPDATE "Oil"`); err != nil {
+			return NewError[bool](err.Error())
+		}
+		return NewError[bool]("insufficient oil inventory", true).WithCause(BAD_REQUEST_ERROR)
+	})
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError().Error()).To(Equal("insufficient oil inventory"))
+	g.Expect(result.RootError().Cause()).To(Equal(BAD_REQUEST_ERROR))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestInTransaction_NestedCallJoinsOuterTransaction(t *testing.T) {
+	g := NewWithT(t)
+	_, uow, mock := setupUnitOfWork(t)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	result := InTransaction(context.Background(), uow, nil, func(ctx context.Context) Result[bool] {
+		outer := TxFromContext(ctx)
+
+		return InTransaction(ctx, uow, nil, func(ctx context.Context) Result[bool] {
+			return Ok(TxFromContext(ctx) == outer)
+		})
+	})
+
+	g.Expect(result.Value()).To(BeTrue())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestUnitOfWork_RollsBackOnPanic(t *testing.T) {
+	g := NewWithT(t)
+	_, uow, mock := setupUnitOfWork(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	g.Expect(func() {
+		_ = uow.Do(context.Background(), nil, func(ctx context.Context) error {
+			panic("boom")
+		})
+	}).To(PanicWith("boom"))
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestDatabaseService_OutsideTransactionUsesDB(t *testing.T) {
+	g := NewWithT(t)
+	db, _, mock := setupUnitOfWork(t)
+
+	mock.ExpectExec(`UPDATE "Oil"`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, err := db.ExecContext(context.Background(), `UPDATE "Oil"`)
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
+)
+
+const dateLayout = "2006-01-02"
+
+type ICompanyUsecase interface {
+	GetProfile(ctx context.Context, companyId int64) Result[*dto.CompanyProfileResponse]
+	GetDeliveries(ctx context.Context, companyId int64, req dto.CompanyDeliveryRequest) Result[*dto.PageResponse[entity.CompanyDelivery]]
+	GetSummary(ctx context.Context, companyId int64, req dto.CompanySummaryRequest) Result[*dto.CompanySummaryResponse]
+	AcknowledgeDelivery(ctx context.Context, companyId int64, deliveryId int64) Result[*entity.CompanyDelivery]
+}
+
+type CompanyUsecase struct {
+	companyRepo repository.ICompanyRepository
+}
+
+func NewCompanyUsecase(companyRepo repository.ICompanyRepository) ICompanyUsecase {
+	return &CompanyUsecase{companyRepo}
+}
+
+var _ ICompanyUsecase = (*CompanyUsecase)(nil)
+
+func (uc *CompanyUsecase) GetProfile(ctx context.Context, companyId int64) Result[*dto.CompanyProfileResponse] {
+	result := uc.companyRepo.FindById(ctx, companyId)
+	if result.IsError() {
+		if result.ExpectedError() != nil {
+			return Wrap[*dto.CompanyProfileResponse](result, "Company not found", true).WithMessage(i18n.COMPANY_NOT_FOUND)
+		}
+		return Wrap[*dto.CompanyProfileResponse](result, "Failed to get company profile")
+	}
+	company := result.Value()
+
+	return Ok(&dto.CompanyProfileResponse{
+		CompanyId:   company.CompanyId,
+		CompanyName: company.CompanyName,
+		UserId:      company.Id,
+		Username:    company.Username,
+		Email:       company.Email,
+		UserType:    company.UserType,
+	})
+}
+
+func (uc *CompanyUsecase) GetDeliveries(ctx context.Context, companyId int64, req dto.CompanyDeliveryRequest) Result[*dto.PageResponse[entity.CompanyDelivery]] {
+	req.Normalize()
+
+	if req.CollectorId < 0 {
+		return NewError[*dto.PageResponse[entity.CompanyDelivery]]("Invalid collector ID", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_COLLECTOR_ID)
+	}
+
+	total := uc.companyRepo.CountDeliveries(ctx, companyId, req.CollectorId)
+	if total.IsError() {
+		logger.FromContext(ctx).Error("failed to count company deliveries", "error", total)
+		return Wrap[*dto.PageResponse[entity.CompanyDelivery]](total, "Failed to count company deliveries").WithMessage(i18n.COMPANY_DELIVERIES_FAILED)
+	}
+
+	result := uc.companyRepo.ListDeliveries(ctx, companyId, req.CollectorId, req.Limit, req.Offset())
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to get company deliveries", "error", result)
+		return Wrap[*dto.PageResponse[entity.CompanyDelivery]](result, "Failed to get company deliveries").WithMessage(i18n.COMPANY_DELIVERIES_FAILED)
+	}
+
+	return Ok(dto.NewPageResponse(result.Value(), req.PageRequest, total.Value()))
+}
+
+func (uc *CompanyUsecase) GetSummary(ctx context.Context, companyId int64, req dto.CompanySummaryRequest) Result[*dto.CompanySummaryResponse] {
+	if req.Period == "" {
+		req.Period = dto.PERIOD_MONTH
+	}
+	if !req.Period.IsValid() {
+		return NewError[*dto.CompanySummaryResponse]("Invalid period, must be one of day, week, month, year", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_PERIOD)
+	}
+
+	// default: 12 bulan terakhir sampai hari ini
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	end := today
+	start := today.AddDate(-1, 0, 0)
+
+	var err error
+	if req.EndDate != "" {
+		end, err = time.ParseInLocation(dateLayout, req.EndDate, time.Local)
+		if err != nil {
+			return NewError[*dto.CompanySummaryResponse]("Invalid end_date, expected format YYYY-MM-DD", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_END_DATE)
+		}
+	}
+	if req.StartDate != "" {
+		start, err = time.ParseInLocation(dateLayout, req.StartDate, time.Local)
+		if err != nil {
+			return NewError[*dto.CompanySummaryResponse]("Invalid start_date, expected format YYYY-MM-DD", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_START_DATE)
+		}
+	}
+	if start.After(end) {
+		return NewError[*dto.CompanySummaryResponse]("start_date must not be after end_date", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.START_DATE_AFTER_END_DATE)
+	}
+
+	// end_date inklusif, jadi batas atas query adalah awal hari berikutnya
+	result := uc.companyRepo.GetPeriodAggregates(ctx, companyId, string(req.Period), start, end.AddDate(0, 0, 1))
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to get company summary", "error", result)
+		return Wrap[*dto.CompanySummaryResponse](result, "Failed to get company summary").WithMessage(i18n.COMPANY_SUMMARY_FAILED)
+	}
+
+	summary := &dto.CompanySummaryResponse{
+		Period:    req.Period,
+		StartDate: start,
+		EndDate:   end,
+		Periods:   result.Value(),
+	}
+	if summary.Periods == nil {
+		summary.Periods = []entity.PeriodAggregate{}
+	}
+
+	for _, p := range summary.Periods {
+		summary.TotalTransactions += p.TotalTransactions
+		summary.TotalVolume += p.TotalVolume
+		summary.TotalSpend += p.TotalAmount
+	}
+
+	return Ok(summary)
+}
+
+func (uc *CompanyUsecase) AcknowledgeDelivery(ctx context.Context, companyId int64, deliveryId int64) Result[*entity.CompanyDelivery] {
+	// pengiriman milik company lain dianggap tidak ada
+	delivery := uc.companyRepo.FindDelivery(ctx, companyId, deliveryId)
+	if delivery.IsError() {
+		if delivery.ExpectedError() != nil {
+			return Err(delivery, "Delivery not found", true).WithMessage(i18n.DELIVERY_NOT_FOUND)
+		}
+		return Err(delivery, "Failed to find delivery")
+	}
+
+	if delivery.Value().IsCancelled() {
+		return NewError[*entity.CompanyDelivery]("Delivery has been cancelled", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.DELIVERY_CANCELLED)
+	}
+
+	if delivery.Value().IsReceived() {
+		return NewError[*entity.CompanyDelivery]("Delivery already acknowledged", true).WithCause(ENTITY_DUPLICATE).WithMessage(i18n.DELIVERY_ALREADY_ACKNOWLEDGED)
+	}
+
+	acknowledged := uc.companyRepo.AcknowledgeDelivery(ctx, companyId, deliveryId)
+	if acknowledged.IsError() {
+		logger.FromContext(ctx).Error("failed to acknowledge delivery", "error", acknowledged)
+		return Wrap[*entity.CompanyDelivery](acknowledged, "Failed to acknowledge delivery").WithMessage(i18n.DELIVERY_ACKNOWLEDGE_FAILED)
+	}
+
+	// request lain sudah lebih dulu mengkonfirmasi atau collector membatalkan pengiriman ini
+	if !acknowledged.Value() {
+		return NewError[*entity.CompanyDelivery]("Delivery already acknowledged or cancelled", true).WithCause(ENTITY_DUPLICATE).WithMessage(i18n.DELIVERY_ACKNOWLEDGE_CONFLICT)
+	}
+
+	return uc.companyRepo.FindDelivery(ctx, companyId, deliveryId)
+}
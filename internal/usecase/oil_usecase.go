@@ -5,6 +5,7 @@ import (
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
 	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
 )
@@ -29,7 +30,7 @@ var _ IOilUsecase = (*OilUsecase)(nil)
 func (uc *OilUsecase) GetOil(ctx context.Context, id int64) Result[*dto.OilResponse] {
 	result := uc.oilRepo.Find(ctx, id)
 	if result.IsError() {
-		return NewError[*dto.OilResponse]("Failed to get oil record", true).WithCause(result.RootError().Cause())
+		return Wrap[*dto.OilResponse](result, "Failed to get oil record", true).WithMessage(i18n.OIL_GET_FAILED)
 	}
 
 	return Ok(mapOilToResponse(result.Value()))
@@ -38,7 +39,7 @@ func (uc *OilUsecase) GetOil(ctx context.Context, id int64) Result[*dto.OilRespo
 func (uc *OilUsecase) GetOilByCollectorId(ctx context.Context, collectorId int64) Result[*dto.OilResponse] {
 	result := uc.oilRepo.GetByCollectorId(ctx, collectorId)
 	if result.IsError() {
-		return NewError[*dto.OilResponse]("Failed to get oil inventory for collector", true).WithCause(result.RootError().Cause())
+		return Wrap[*dto.OilResponse](result, "Failed to get oil inventory for collector", true).WithMessage(i18n.OIL_INVENTORY_FAILED)
 	}
 
 	return Ok(mapOilToResponse(result.Value()))
@@ -47,12 +48,12 @@ func (uc *OilUsecase) GetOilByCollectorId(ctx context.Context, collectorId int64
 func (uc *OilUsecase) UpdateOil(ctx context.Context, id int64, totalVolume float64) Result[*dto.OilResponse] {
 
 	if totalVolume < 0 {
-		return NewError[*dto.OilResponse]("Total volume cannot be negative", true).WithCause(INTERNAL_LOGIC_ERROR)
+		return NewError[*dto.OilResponse]("Total volume cannot be negative", true).WithCause(INTERNAL_LOGIC_ERROR).WithMessage(i18n.OIL_VOLUME_NEGATIVE)
 	}
 
 	existingResult := uc.oilRepo.Find(ctx, id)
 	if existingResult.IsError() {
-		return NewError[*dto.OilResponse]("Failed to find oil record", true).WithCause(existingResult.RootError().Cause())
+		return Wrap[*dto.OilResponse](existingResult, "Failed to find oil record", true).WithMessage(i18n.OIL_GET_FAILED)
 	}
 
 	oil := existingResult.Value()
@@ -64,7 +65,7 @@ func (uc *OilUsecase) UpdateOil(ctx context.Context, id int64, totalVolume float
 
 	result := uc.oilRepo.Update(ctx, oil)
 	if result.IsError() {
-		return NewError[*dto.OilResponse]("Failed to update oil record", true).WithCause(result.RootError().Cause())
+		return Wrap[*dto.OilResponse](result, "Failed to update oil record", true).WithMessage(i18n.OIL_UPDATE_FAILED)
 	}
 
 	return Ok(mapOilToResponse(result.Value()))
@@ -73,7 +74,7 @@ func (uc *OilUsecase) UpdateOil(ctx context.Context, id int64, totalVolume float
 func (uc *OilUsecase) DeleteOil(ctx context.Context, id int64) Result[bool] {
 	result := uc.oilRepo.Delete(ctx, id)
 	if result.IsError() {
-		return Err(result, "Failed to delete oil record", true)
+		return Err(result, "Failed to delete oil record", true).WithMessage(i18n.OIL_DELETE_FAILED)
 	}
 
 	return Ok(true)
@@ -2,10 +2,11 @@ package usecase
 
 import (
 	"context"
-	"log"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
 	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
 )
@@ -33,20 +34,20 @@ func (uc *ReportUsecase) GetReportByDate(ctx context.Context, reportDto *dto.Rep
 	case dto.REPORT_SALES:
 		result := uc.reportRepo.GetSalesReport(ctx, startDate, endDate)
 		if result.IsError() {
-			log.Println(result.Error())
-			return Err(result, "Failed to get sales report", true)
+			logger.FromContext(ctx).Error("failed to get sales report", "error", result)
+			return Err(result, "Failed to get sales report", true).WithMessage(i18n.SALES_REPORT_FAILED)
 		}
 		return result
 	case dto.REPORT_PURCHASE:
 		result := uc.reportRepo.GetPurchasesReport(ctx, startDate, endDate)
 		if result.IsError() {
-			log.Println(result.Error())
-			return Err(result, "Failed to get purchases report", true)
+			logger.FromContext(ctx).Error("failed to get purchases report", "error", result)
+			return Err(result, "Failed to get purchases report", true).WithMessage(i18n.PURCHASES_REPORT_FAILED)
 		}
 		return result
 	}
 
-	return NewError[[]entity.ReportTransaction]("Invalid report type", true).WithCause(UNKNOWN_ERROR)
+	return NewError[[]entity.ReportTransaction]("Invalid report type", true).WithCause(UNKNOWN_ERROR).WithMessage(i18n.INVALID_REPORT_TYPE)
 }
 
 func (uc *ReportUsecase) GetAllReports(ctx context.Context, reportDto *dto.ReportAll) Result[[]entity.ReportTransaction] {
@@ -54,18 +55,18 @@ func (uc *ReportUsecase) GetAllReports(ctx context.Context, reportDto *dto.Repor
 	case dto.REPORT_SALES:
 		result := uc.reportRepo.GetAllSalesReport(ctx)
 		if result.IsError() {
-			log.Println(result.Error())
-			return Err(result, "Failed to get all sales report", true)
+			logger.FromContext(ctx).Error("failed to get all sales report", "error", result)
+			return Err(result, "Failed to get all sales report", true).WithMessage(i18n.SALES_REPORT_FAILED)
 		}
 		return result
 	case dto.REPORT_PURCHASE:
 		result := uc.reportRepo.GetAllPurchasesReport(ctx)
 		if result.IsError() {
-			log.Println(result.Error())
-			return Err(result, "Failed to get all purchases report", true)
+			logger.FromContext(ctx).Error("failed to get all purchases report", "error", result)
+			return Err(result, "Failed to get all purchases report", true).WithMessage(i18n.PURCHASES_REPORT_FAILED)
 		}
 		return result
 	}
 
-	return NewError[[]entity.ReportTransaction]("Invalid report type", true).WithCause(UNKNOWN_ERROR)
+	return NewError[[]entity.ReportTransaction]("Invalid report type", true).WithCause(UNKNOWN_ERROR).WithMessage(i18n.INVALID_REPORT_TYPE)
 }
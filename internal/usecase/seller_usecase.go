@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
+)
+
+type ISellerUsecase interface {
+	GetProfile(ctx context.Context, sellerId int64) Result[*dto.SellerProfileResponse]
+	GetTransactions(ctx context.Context, sellerId int64, page dto.PageRequest) Result[*dto.PageResponse[entity.SellerTransactionHistory]]
+	GetSummary(ctx context.Context, sellerId int64) Result[*entity.SellerSummary]
+	GetMonthlySummary(ctx context.Context, sellerId int64, year int) Result[[]entity.MonthlyAggregate]
+}
+
+type SellerUsecase struct {
+	sellerRepo repository.ISellerRepository
+}
+
+func NewSellerUsecase(sellerRepo repository.ISellerRepository) ISellerUsecase {
+	return &SellerUsecase{sellerRepo}
+}
+
+var _ ISellerUsecase = (*SellerUsecase)(nil)
+
+func (uc *SellerUsecase) GetProfile(ctx context.Context, sellerId int64) Result[*dto.SellerProfileResponse] {
+	result := uc.sellerRepo.FindById(ctx, sellerId)
+	if result.IsError() {
+		if result.ExpectedError() != nil {
+			return Wrap[*dto.SellerProfileResponse](result, "Seller not found", true).WithMessage(i18n.SELLER_NOT_FOUND)
+		}
+		return Wrap[*dto.SellerProfileResponse](result, "Failed to get seller profile")
+	}
+	seller := result.Value()
+
+	return Ok(&dto.SellerProfileResponse{
+		SellerId:   seller.SellerId,
+		SellerName: seller.SellerName,
+		UserId:     seller.Id,
+		Username:   seller.Username,
+		Email:      seller.Email,
+		UserType:   seller.UserType,
+	})
+}
+
+func (uc *SellerUsecase) GetTransactions(ctx context.Context, sellerId int64, page dto.PageRequest) Result[*dto.PageResponse[entity.SellerTransactionHistory]] {
+	page.Normalize()
+
+	total := uc.sellerRepo.CountTransactions(ctx, sellerId)
+	if total.IsError() {
+		logger.FromContext(ctx).Error("failed to count seller transactions", "error", total)
+		return Wrap[*dto.PageResponse[entity.SellerTransactionHistory]](total, "Failed to count seller transactions").WithMessage(i18n.SELLER_TRANSACTIONS_FAILED)
+	}
+
+	result := uc.sellerRepo.ListTransactions(ctx, sellerId, page.Limit, page.Offset())
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to get seller transactions", "error", result)
+		return Wrap[*dto.PageResponse[entity.SellerTransactionHistory]](result, "Failed to get seller transactions").WithMessage(i18n.SELLER_TRANSACTIONS_FAILED)
+	}
+
+	return Ok(dto.NewPageResponse(result.Value(), page, total.Value()))
+}
+
+func (uc *SellerUsecase) GetSummary(ctx context.Context, sellerId int64) Result[*entity.SellerSummary] {
+	result := uc.sellerRepo.GetSummary(ctx, sellerId)
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to get seller summary", "error", result)
+		return Err(result, "Failed to get seller summary").WithMessage(i18n.SELLER_SUMMARY_FAILED)
+	}
+
+	return result
+}
+
+func (uc *SellerUsecase) GetMonthlySummary(ctx context.Context, sellerId int64, year int) Result[[]entity.MonthlyAggregate] {
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	if year < 2000 || year > 9999 {
+		return NewError[[]entity.MonthlyAggregate]("Invalid year", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_YEAR)
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(1, 0, 0)
+
+	result := uc.sellerRepo.GetMonthlyAggregates(ctx, sellerId, start, end)
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to get seller monthly summary", "error", result)
+		return Err(result, "Failed to get seller monthly summary").WithMessage(i18n.SELLER_MONTHLY_SUMMARY_FAILED)
+	}
+
+	if result.Value() == nil {
+		return Ok([]entity.MonthlyAggregate{})
+	}
+
+	return result
+}
@@ -2,70 +2,215 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"strings"
+	"time"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/metrics"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/validator"
 	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
 )
 
 type ITransactionUsecase interface {
 	CreateTransaction(ctx context.Context, collectorId int64, dto *dto.TransactionCreateDto) Result[*dto.TransactionResponse]
+	// CreateTransactionIdempotent sama seperti CreateTransaction, tetapi retry dengan key dan body
+	// yang sama mengembalikan respons pertama tanpa membuat transaksi baru
+	CreateTransactionIdempotent(ctx context.Context, collectorId int64, key string, dto *dto.TransactionCreateDto) Result[*dto.IdempotentResult[*dto.TransactionResponse]]
 	UpdateTransaction(ctx context.Context, collectorId int64, id int64, updateDto *dto.UpdateTransactionDto) Result[*dto.TransactionResponse]
+	ListTransactions(ctx context.Context, collectorId int64, req dto.TransactionListRequest) Result[*dto.CursorPageResponse[entity.TransactionHistory]]
+	CancelTransaction(ctx context.Context, collectorId int64, id int64, cancelDto *dto.CancelTransactionDto) Result[*dto.TransactionResponse]
+	// SyncTransactions membuat transaksi dari perangkat offline sesuai urutan item.
+	// Setiap item diproses dalam database transaction sendiri, item yang gagal tidak membatalkan item lain.
+	SyncTransactions(ctx context.Context, collectorId int64, req *dto.SyncTransactionRequest) Result[*dto.SyncTransactionResponse]
 }
 
 type TransactionUsecase struct {
 	transactionRepo repository.ITransactionRepository
 	userRepo        repository.IUserRepository
+	oilRepo         repository.IOilRepository
+	idempotencyRepo repository.IIdempotencyKeyRepository
+	syncRepo        repository.ISyncedTransactionRepository
+	uow             services.UnitOfWork
+	metrics         *metrics.Metrics
+	cfg             *config.Config
 }
 
-func NewTransactionUsecase(transactionRepo repository.ITransactionRepository, userRepo repository.IUserRepository) ITransactionUsecase {
-	return &TransactionUsecase{transactionRepo, userRepo}
+func NewTransactionUsecase(
+	transactionRepo repository.ITransactionRepository,
+	userRepo repository.IUserRepository,
+	oilRepo repository.IOilRepository,
+	idempotencyRepo repository.IIdempotencyKeyRepository,
+	syncRepo repository.ISyncedTransactionRepository,
+	uow services.UnitOfWork,
+	metrics *metrics.Metrics,
+	cfg *config.Config,
+) ITransactionUsecase {
+	return &TransactionUsecase{transactionRepo, userRepo, oilRepo, idempotencyRepo, syncRepo, uow, metrics, cfg}
 }
 
+// transaksi yang mengubah stok Oil dijalankan dengan READ COMMITTED, baris yang diubah
+// dikunci secara eksplisit (transaksi lalu Oil) sehingga tidak perlu isolation level yang lebih tinggi
+var inventoryTxOptions = &sql.TxOptions{Isolation: sql.LevelReadCommitted}
+
 var _ ITransactionUsecase = (*TransactionUsecase)(nil)
 
 func (uc *TransactionUsecase) CreateTransaction(ctx context.Context, collectorId int64, dtoo *dto.TransactionCreateDto) Result[*dto.TransactionResponse] {
+	result := uc.createTransaction(ctx, collectorId, dtoo)
+	if !result.IsError() {
+		uc.recordCreated(result.Value())
+	}
+
+	return result
+}
+
+func (uc *TransactionUsecase) createTransaction(ctx context.Context, collectorId int64, dtoo *dto.TransactionCreateDto) Result[*dto.TransactionResponse] {
 	switch dtoo.TransactionType {
 	case dto.TRANSACTION_SELL:
-		return uc.createSellTransaction(ctx, collectorId, dtoo)
+		return uc.createSellTransaction(ctx, collectorId, dtoo, time.Time{})
 	case dto.TRANSACTION_BUY:
-		return uc.createDistributeTransaction(ctx, collectorId, dtoo)
+		return uc.createDistributeTransaction(ctx, collectorId, dtoo, time.Time{})
 	default:
-		return NewError[*dto.TransactionResponse]("Invalid transaction type", true).WithCause(BAD_REQUEST_ERROR)
+		return NewError[*dto.TransactionResponse]("Invalid transaction type", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_TRANSACTION_TYPE)
 	}
 }
 
-func (uc *TransactionUsecase) createSellTransaction(ctx context.Context, collectorId int64, txDto *dto.TransactionCreateDto) Result[*dto.TransactionResponse] {
-	result := uc.userRepo.FindByEmailWithSeller(ctx, txDto.Email)
-	if result.IsError() {
-		return NewError[*dto.TransactionResponse]("User seller not found", true).WithCause(ENTITY_NOT_FOUND)
+func (uc *TransactionUsecase) CreateTransactionIdempotent(ctx context.Context, collectorId int64, key string, txDto *dto.TransactionCreateDto) Result[*dto.IdempotentResult[*dto.TransactionResponse]] {
+	if key == "" || len(key) > config.IDEMPOTENCY_KEY_MAX_LENGTH {
+		return NewError[*dto.IdempotentResult[*dto.TransactionResponse]](
+			fmt.Sprintf("Idempotency key must be between 1 and %d characters", config.IDEMPOTENCY_KEY_MAX_LENGTH),
+			true,
+		).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.IDEMPOTENCY_KEY_INVALID_LENGTH, config.IDEMPOTENCY_KEY_MAX_LENGTH)
 	}
-	userWithSeller := result.Value()
 
-	if txDto.OilVolume <= 0 {
-		return NewError[*dto.TransactionResponse]("Volume must be greater than 0", true).WithCause(INTERNAL_LOGIC_ERROR)
+	requestHash, err := hashRequest(txDto)
+	if err != nil {
+		return Wrap[*dto.IdempotentResult[*dto.TransactionResponse]](err, "Failed to hash request")
+	}
+
+	// key diklaim di dalam transaction yang sama dengan pembuatan transaksi. Jika pembuatan gagal,
+	// klaimnya ikut di-rollback sehingga request berikutnya dengan key yang sama bisa mencoba lagi.
+	result := services.InTransaction(ctx, uc.uow, nil, func(ctx context.Context) Result[*dto.IdempotentResult[*dto.TransactionResponse]] {
+		expiresAt := time.Now().Add(uc.cfg.IDEMPOTENCY_KEY_TTL)
+
+		claimed := uc.idempotencyRepo.Claim(ctx, collectorId, key, requestHash, expiresAt)
+		if claimed.IsError() {
+			if e := claimed.ExpectedError(); e != nil {
+				return ErrorFrom[*dto.IdempotentResult[*dto.TransactionResponse]](e)
+			}
+			return Wrap[*dto.IdempotentResult[*dto.TransactionResponse]](claimed, "Failed to claim idempotency key").WithMessage(i18n.IDEMPOTENCY_KEY_FAILED)
+		}
+
+		if !claimed.Value() {
+			return uc.replayTransaction(ctx, collectorId, key, requestHash)
+		}
+
+		result := uc.createTransaction(ctx, collectorId, txDto)
+		if e := result.RootError(); e != nil {
+			return ErrorFrom[*dto.IdempotentResult[*dto.TransactionResponse]](e)
+		}
+
+		body, err := json.Marshal(result.Value())
+		if err != nil {
+			return Wrap[*dto.IdempotentResult[*dto.TransactionResponse]](err, "Failed to encode response")
+		}
+
+		saved := uc.idempotencyRepo.SaveResponse(ctx, collectorId, key, body)
+		if saved.IsError() {
+			return Wrap[*dto.IdempotentResult[*dto.TransactionResponse]](saved, "Failed to store idempotent response").WithMessage(i18n.IDEMPOTENCY_KEY_FAILED)
+		}
+
+		return Ok(&dto.IdempotentResult[*dto.TransactionResponse]{Value: result.Value()})
+	})
+
+	// metrics hanya dicatat setelah commit, respons yang diputar ulang tidak dihitung lagi
+	if !result.IsError() && !result.Value().Replayed {
+		uc.recordCreated(result.Value().Value)
 	}
-	if txDto.Price <= 0 {
-		return NewError[*dto.TransactionResponse]("Price must be greater than 0", true).WithCause(INTERNAL_LOGIC_ERROR)
+
+	return result
+}
+
+func (uc *TransactionUsecase) recordCreated(transaction *dto.TransactionResponse) {
+	uc.metrics.TransactionCreated(transaction.TransactionType, transaction.OilVolume)
+}
+
+func (uc *TransactionUsecase) replayTransaction(ctx context.Context, collectorId int64, key string, requestHash string) Result[*dto.IdempotentResult[*dto.TransactionResponse]] {
+	found := uc.idempotencyRepo.Find(ctx, collectorId, key)
+	if found.IsError() {
+		return Wrap[*dto.IdempotentResult[*dto.TransactionResponse]](found, "Failed to get idempotency key").WithMessage(i18n.IDEMPOTENCY_KEY_FAILED)
 	}
+	idempotencyKey := found.Value()
+
+	if idempotencyKey.RequestHash != requestHash {
+		return NewError[*dto.IdempotentResult[*dto.TransactionResponse]](
+			"Idempotency key has already been used with a different request body",
+			true,
+		).WithCause(UNPROCESSABLE_ENTITY_ERROR).WithMessage(i18n.IDEMPOTENCY_KEY_MISMATCH)
+	}
+
+	if !idempotencyKey.IsCompleted() {
+		return NewError[*dto.IdempotentResult[*dto.TransactionResponse]](
+			"A request with this idempotency key is still being processed",
+			true,
+		).WithCause(ENTITY_DUPLICATE).WithMessage(i18n.IDEMPOTENCY_KEY_IN_PROGRESS)
+	}
+
+	response := new(dto.TransactionResponse)
+	if err := json.Unmarshal(idempotencyKey.ResponseBody, response); err != nil {
+		return Wrap[*dto.IdempotentResult[*dto.TransactionResponse]](err, "Failed to decode stored response")
+	}
+
+	logger.FromContext(ctx).Debug("idempotent response replayed", "idempotency_key", key)
+	return Ok(&dto.IdempotentResult[*dto.TransactionResponse]{Value: response, Replayed: true})
+}
+
+// hashRequest menghasilkan sha256 dari body yang sudah di-parse, jadi perbedaan spasi
+// atau urutan field JSON tidak dianggap sebagai body yang berbeda
+func hashRequest(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// createdAt kosong berarti waktu transaksi diisi oleh database
+func (uc *TransactionUsecase) createSellTransaction(ctx context.Context, collectorId int64, txDto *dto.TransactionCreateDto, createdAt time.Time) Result[*dto.TransactionResponse] {
+	result := uc.userRepo.FindByEmailWithSeller(ctx, txDto.Email)
+	if result.IsError() {
+		return NewError[*dto.TransactionResponse]("User seller not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.SELLER_USER_NOT_FOUND)
+	}
+	userWithSeller := result.Value()
 
 	tx := &entity.SellTransaction{
 		SellerId:    userWithSeller.SellerId,
 		CollectorId: collectorId,
 		Price:       txDto.Price,
 		Volume:      txDto.OilVolume,
+		CreatedAt:   createdAt,
 	}
 
 	res := uc.transactionRepo.CreateSellTransaction(ctx, tx)
 	if res.IsError() {
-		log.Println(res.Error())
-		return NewError[*dto.TransactionResponse](
-			"Failed to create sell transaction",
-			true,
-		).WithCause(INTERNAL_SERVICE_ERROR)
+		if e := res.ExpectedError(); e != nil {
+			return ErrorFrom[*dto.TransactionResponse](e)
+		}
+
+		return Wrap[*dto.TransactionResponse](res, "Failed to create sell transaction").WithMessage(i18n.TRANSACTION_CREATE_FAILED)
 	}
 
 	transaction := res.Value()
@@ -82,42 +227,32 @@ func (uc *TransactionUsecase) createSellTransaction(ctx context.Context, collect
 	return Ok(response)
 }
 
-func (uc *TransactionUsecase) createDistributeTransaction(ctx context.Context, collectorId int64, txDto *dto.TransactionCreateDto) Result[*dto.TransactionResponse] {
+func (uc *TransactionUsecase) createDistributeTransaction(ctx context.Context, collectorId int64, txDto *dto.TransactionCreateDto, createdAt time.Time) Result[*dto.TransactionResponse] {
 	result := uc.userRepo.FindByEmailWithCompany(ctx, txDto.Email)
 	if e := result.LastError(); e != nil {
-		return NewError[*dto.TransactionResponse](e.Error(), e.IsExpected).WithCause(e.Cause())
+		return ErrorFrom[*dto.TransactionResponse](e)
 	}
 	userWithCompany := result.Value()
 
-	if txDto.OilVolume <= 0 {
-		return NewError[*dto.TransactionResponse]("Volume must be greater than 0", true).WithCause(INTERNAL_LOGIC_ERROR)
-	}
-	if txDto.Price <= 0 {
-		return NewError[*dto.TransactionResponse]("Price must be greater than 0", true).WithCause(INTERNAL_LOGIC_ERROR)
-	}
-
 	tx := &entity.DistributeTransaction{
 		CollectorId: collectorId,
 		CompanyId:   userWithCompany.CompanyId,
 		Volume:      txDto.OilVolume,
 		Price:       txDto.Price,
+		CreatedAt:   createdAt,
 	}
 
 	res := uc.transactionRepo.CreateDistributeTransaction(ctx, tx)
 	if res.IsError() {
-		log.Println(res.Error())
-
-		if res.RootError() != nil && res.RootError().Cause() == INTERNAL_SERVICE_ERROR {
-			return NewError[*dto.TransactionResponse](
-				"Failed to create distribute transaction. Insufficient oil inventory or invalid company ID",
-				true,
-			).WithCause(INTERNAL_SERVICE_ERROR)
+		// stok yang tidak cukup dan company yang tidak ada dikirim apa adanya supaya client tahu alasannya
+		if e := res.ExpectedError(); e != nil {
+			if e.MessageId() == i18n.INSUFFICIENT_OIL_INVENTORY {
+				uc.metrics.InsufficientInventory()
+			}
+			return ErrorFrom[*dto.TransactionResponse](e)
 		}
 
-		return NewError[*dto.TransactionResponse](
-			"Failed to create distribute transaction",
-			true,
-		).WithCause(INTERNAL_SERVICE_ERROR)
+		return Wrap[*dto.TransactionResponse](res, "Failed to create distribute transaction").WithMessage(i18n.TRANSACTION_CREATE_FAILED)
 	}
 
 	transaction := res.Value()
@@ -135,105 +270,475 @@ func (uc *TransactionUsecase) createDistributeTransaction(ctx context.Context, c
 }
 
 func (uc *TransactionUsecase) UpdateTransaction(ctx context.Context, collectorId int64, id int64, updateDto *dto.UpdateTransactionDto) Result[*dto.TransactionResponse] {
-	if updateDto.OilVolume <= 0 {
-		return NewError[*dto.TransactionResponse]("Volume must be greater than 0", true).WithCause(INTERNAL_LOGIC_ERROR)
-	}
-	if updateDto.Price <= 0 {
-		return NewError[*dto.TransactionResponse]("Price must be greater than 0", true).WithCause(INTERNAL_LOGIC_ERROR)
-	}
-
 	switch updateDto.TransactionType {
 	case dto.TRANSACTION_SELL:
 		return uc.updateSellTransaction(ctx, collectorId, id, updateDto)
 	case dto.TRANSACTION_BUY:
 		return uc.updateDistributeTransaction(ctx, collectorId, id, updateDto)
 	default:
-		return NewError[*dto.TransactionResponse]("Invalid transaction type", true).WithCause(BAD_REQUEST_ERROR)
+		return NewError[*dto.TransactionResponse]("Invalid transaction type", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_TRANSACTION_TYPE)
 	}
 }
 
 func (uc *TransactionUsecase) updateSellTransaction(ctx context.Context, collectorId int64, id int64, updateDto *dto.UpdateTransactionDto) Result[*dto.TransactionResponse] {
-	// First, verify the transaction exists
-	findResult := uc.transactionRepo.FindSellTransactionById(ctx, id)
-	if findResult.IsError() {
-		return NewError[*dto.TransactionResponse](
-			fmt.Sprintf("Sell transaction with id %d not found", id),
-			true,
-		).WithCause(ENTITY_NOT_FOUND)
-	}
+	return services.InTransaction(ctx, uc.uow, inventoryTxOptions, func(ctx context.Context) Result[*dto.TransactionResponse] {
+		// First, verify the transaction exists and lock it until the update is committed
+		findResult := uc.transactionRepo.LockSellTransactionById(ctx, id)
+		if findResult.IsError() && !errors.Is(findResult, ENTITY_NOT_FOUND) {
+			return Wrap[*dto.TransactionResponse](findResult, "Failed to find sell transaction").WithMessage(i18n.TRANSACTION_UPDATE_FAILED)
+		}
+		if findResult.IsError() {
+			return NewError[*dto.TransactionResponse](
+				fmt.Sprintf("Sell transaction with id %d not found", id),
+				true,
+			).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.SELL_TRANSACTION_NOT_FOUND, id)
+		}
 
-	// Verify the transaction belongs to this collector
-	existingTransaction := findResult.Value()
-	if existingTransaction.CollectorId != collectorId {
-		return NewError[*dto.TransactionResponse](
-			"You are not authorized to update this transaction",
-			true,
-		).WithCause(CREDENTIALS_ERROR)
+		// Verify the transaction belongs to this collector
+		existingTransaction := findResult.Value()
+		if existingTransaction.CollectorId != collectorId {
+			return NewError[*dto.TransactionResponse](
+				"You are not authorized to update this transaction",
+				true,
+			).WithCause(FORBIDDEN_ERROR).WithMessage(i18n.TRANSACTION_UPDATE_FORBIDDEN)
+		}
+
+		if existingTransaction.IsCancelled() {
+			return NewError[*dto.TransactionResponse](
+				"Cancelled transaction cannot be updated",
+				true,
+			).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.TRANSACTION_CANCELLED)
+		}
+
+		// pembelian dari seller menambah stok, jadi stok bertambah sebesar volume baru dikurangi volume lama
+		adjusted := uc.oilRepo.AdjustVolume(ctx, collectorId, updateDto.OilVolume, existingTransaction.Volume)
+		if e := adjusted.RootError(); e != nil {
+			if e.Cause() == BAD_REQUEST_ERROR {
+				return NewError[*dto.TransactionResponse](
+					"Cannot update transaction, collector oil inventory would become negative",
+					true,
+				).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.TRANSACTION_UPDATE_NEGATIVE_INVENTORY)
+			}
+
+			return Wrap[*dto.TransactionResponse](adjusted, "Failed to adjust oil inventory").WithMessage(i18n.OIL_ADJUST_FAILED)
+		}
+
+		// Update the transaction
+		result := uc.transactionRepo.UpdateSellTransaction(ctx, id, updateDto.OilVolume, updateDto.Price)
+		if result.IsError() {
+			if e := result.ExpectedError(); e != nil {
+				return ErrorFrom[*dto.TransactionResponse](e)
+			}
+			return Wrap[*dto.TransactionResponse](result, "Failed to update sell transaction").WithMessage(i18n.TRANSACTION_UPDATE_FAILED)
+		}
+
+		transaction := result.Value()
+		response := &dto.TransactionResponse{
+			Id:              transaction.Id,
+			SellerId:        transaction.SellerId,
+			OilVolume:       transaction.Volume,
+			Price:           transaction.Price,
+			TransactionType: dto.TRANSACTION_SELL,
+			CreatedAt:       transaction.CreatedAt,
+			UpdatedAt:       transaction.UpdatedAt,
+		}
+
+		return Ok(response)
+	})
+}
+
+func (uc *TransactionUsecase) updateDistributeTransaction(ctx context.Context, collectorId int64, id int64, updateDto *dto.UpdateTransactionDto) Result[*dto.TransactionResponse] {
+	return services.InTransaction(ctx, uc.uow, inventoryTxOptions, func(ctx context.Context) Result[*dto.TransactionResponse] {
+		// First, verify the transaction exists and lock it until the update is committed
+		findResult := uc.transactionRepo.LockDistributeTransactionById(ctx, id)
+		if findResult.IsError() && !errors.Is(findResult, ENTITY_NOT_FOUND) {
+			return Wrap[*dto.TransactionResponse](findResult, "Failed to find distribute transaction").WithMessage(i18n.TRANSACTION_UPDATE_FAILED)
+		}
+		if findResult.IsError() {
+			return NewError[*dto.TransactionResponse](
+				fmt.Sprintf("Distribute transaction with id %d not found", id),
+				true,
+			).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.DISTRIBUTE_TRANSACTION_NOT_FOUND, id)
+		}
+
+		// Verify the transaction belongs to this collector
+		existingTransaction := findResult.Value()
+		if existingTransaction.CollectorId != collectorId {
+			return NewError[*dto.TransactionResponse](
+				"You are not authorized to update this transaction",
+				true,
+			).WithCause(FORBIDDEN_ERROR).WithMessage(i18n.TRANSACTION_UPDATE_FORBIDDEN)
+		}
+
+		if existingTransaction.IsCancelled() {
+			return NewError[*dto.TransactionResponse](
+				"Cancelled transaction cannot be updated",
+				true,
+			).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.TRANSACTION_CANCELLED)
+		}
+
+		// distribusi ke company mengurangi stok, jadi arah penyesuaiannya kebalikan dari SELL
+		adjusted := uc.oilRepo.AdjustVolume(ctx, collectorId, existingTransaction.Volume, updateDto.OilVolume)
+		if e := adjusted.RootError(); e != nil {
+			if e.Cause() == BAD_REQUEST_ERROR {
+				return NewError[*dto.TransactionResponse](
+					"Cannot update transaction, collector oil inventory would become negative",
+					true,
+				).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.TRANSACTION_UPDATE_NEGATIVE_INVENTORY)
+			}
+
+			return Wrap[*dto.TransactionResponse](adjusted, "Failed to adjust oil inventory").WithMessage(i18n.OIL_ADJUST_FAILED)
+		}
+
+		// Update the transaction
+		result := uc.transactionRepo.UpdateDistributeTransaction(ctx, id, updateDto.OilVolume, updateDto.Price)
+		if result.IsError() {
+			if e := result.ExpectedError(); e != nil {
+				return ErrorFrom[*dto.TransactionResponse](e)
+			}
+			return Wrap[*dto.TransactionResponse](result, "Failed to update distribute transaction").WithMessage(i18n.TRANSACTION_UPDATE_FAILED)
+		}
+
+		transaction := result.Value()
+		response := &dto.TransactionResponse{
+			Id:              transaction.Id,
+			CompanyId:       transaction.CompanyId,
+			OilVolume:       transaction.Volume,
+			Price:           transaction.Price,
+			TransactionType: dto.TRANSACTION_BUY,
+			CreatedAt:       transaction.CreatedAt,
+			UpdatedAt:       transaction.UpdatedAt,
+		}
+
+		return Ok(response)
+	})
+}
+
+func (uc *TransactionUsecase) ListTransactions(ctx context.Context, collectorId int64, req dto.TransactionListRequest) Result[*dto.CursorPageResponse[entity.TransactionHistory]] {
+	filter := newTransactionFilter(collectorId, req)
+	if e := filter.RootError(); e != nil {
+		return ErrorFrom[*dto.CursorPageResponse[entity.TransactionHistory]](e)
 	}
+	f := filter.Value()
+
+	limit := f.Limit
+	// ambil satu baris lebih untuk mengetahui apakah masih ada halaman berikutnya
+	f.Limit++
 
-	// Update the transaction
-	result := uc.transactionRepo.UpdateSellTransaction(ctx, id, updateDto.OilVolume, updateDto.Price)
+	result := uc.transactionRepo.ListTransactions(ctx, f)
 	if result.IsError() {
-		log.Println(result.Error())
-		return NewError[*dto.TransactionResponse](
-			"Failed to update sell transaction",
-			true,
-		).WithCause(INTERNAL_SERVICE_ERROR)
+		return Wrap[*dto.CursorPageResponse[entity.TransactionHistory]](result, "Failed to list transactions").WithMessage(i18n.TRANSACTION_LIST_FAILED)
 	}
 
-	transaction := result.Value()
-	response := &dto.TransactionResponse{
-		Id:              transaction.Id,
-		SellerId:        transaction.SellerId,
-		OilVolume:       transaction.Volume,
-		Price:           transaction.Price,
-		TransactionType: dto.TRANSACTION_SELL,
-		CreatedAt:       transaction.CreatedAt,
-		UpdatedAt:       transaction.UpdatedAt,
+	items := result.Value()
+	if items == nil {
+		items = []entity.TransactionHistory{}
+	}
+
+	page := &dto.CursorPageResponse[entity.TransactionHistory]{Limit: limit}
+	if len(items) > limit {
+		items = items[:limit]
+		page.HasMore = true
+
+		cursor := entity.TransactionCursor{SortBy: f.SortBy, Descending: f.Descending}
+		next, err := dto.EncodeCursor(cursor.CursorOf(items[len(items)-1]))
+		if err != nil {
+			return Wrap[*dto.CursorPageResponse[entity.TransactionHistory]](err, "Failed to encode cursor")
+		}
+		page.NextCursor = next
+	}
+	page.Items = items
+
+	return Ok(page)
+}
+
+// newTransactionFilter mengubah query parameter menjadi filter repository.
+// Nilai filter sudah divalidasi lewat tag validate pada TransactionListRequest.
+func newTransactionFilter(collectorId int64, req dto.TransactionListRequest) Result[entity.TransactionFilter] {
+	filter := entity.TransactionFilter{
+		CollectorId:       collectorId,
+		TransactionType:   string(req.Type),
+		CounterpartyId:    req.CounterpartyId,
+		CounterpartyEmail: req.Email,
+		MinVolume:         req.MinVolume,
+		MaxVolume:         req.MaxVolume,
+		MinPrice:          req.MinPrice,
+		MaxPrice:          req.MaxPrice,
+		SortBy:            req.Sort,
+		Descending:        req.Order != dto.SORT_ASC,
+		Limit:             req.Limit,
+	}
+
+	if filter.SortBy == "" {
+		filter.SortBy = entity.SORT_BY_CREATED_AT
+	}
+
+	if req.StartDate != "" {
+		start, err := time.ParseInLocation(dateLayout, req.StartDate, time.Local)
+		if err != nil {
+			return NewError[entity.TransactionFilter]("Invalid start_date, expected format YYYY-MM-DD", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_START_DATE)
+		}
+		filter.StartDate = &start
+	}
+	if req.EndDate != "" {
+		end, err := time.ParseInLocation(dateLayout, req.EndDate, time.Local)
+		if err != nil {
+			return NewError[entity.TransactionFilter]("Invalid end_date, expected format YYYY-MM-DD", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_END_DATE)
+		}
+		// end_date inklusif
+		end = end.AddDate(0, 0, 1)
+		filter.EndDate = &end
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && !filter.StartDate.Before(*filter.EndDate) {
+		return NewError[entity.TransactionFilter]("start_date must not be after end_date", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.START_DATE_AFTER_END_DATE)
+	}
+
+	if filter.Limit < 1 {
+		filter.Limit = dto.DEFAULT_PAGE_LIMIT
+	}
+	if filter.Limit > dto.MAX_PAGE_LIMIT {
+		filter.Limit = dto.MAX_PAGE_LIMIT
+	}
+
+	if req.Cursor != "" {
+		cursor := new(entity.TransactionCursor)
+		if err := dto.DecodeCursor(req.Cursor, cursor); err != nil {
+			return NewError[entity.TransactionFilter]("Invalid cursor", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_CURSOR)
+		}
+		// cursor hanya berlaku untuk urutan yang sama dengan saat cursor dibuat
+		if cursor.SortBy != filter.SortBy || cursor.Descending != filter.Descending {
+			return NewError[entity.TransactionFilter]("Cursor does not match the requested sort", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.CURSOR_SORT_MISMATCH)
+		}
+		filter.After = cursor
+	}
+
+	return Ok(filter)
+}
+
+func (uc *TransactionUsecase) CancelTransaction(ctx context.Context, collectorId int64, id int64, cancelDto *dto.CancelTransactionDto) Result[*dto.TransactionResponse] {
+	cancelDto.Reason = strings.TrimSpace(cancelDto.Reason)
+
+	switch cancelDto.TransactionType {
+	case dto.TRANSACTION_SELL:
+		return uc.cancelSellTransaction(ctx, collectorId, id, cancelDto.Reason)
+	case dto.TRANSACTION_BUY:
+		return uc.cancelDistributeTransaction(ctx, collectorId, id, cancelDto.Reason)
+	default:
+		return NewError[*dto.TransactionResponse]("Invalid transaction type", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INVALID_TRANSACTION_TYPE)
+	}
+}
+
+func (uc *TransactionUsecase) cancelSellTransaction(ctx context.Context, collectorId int64, id int64, reason string) Result[*dto.TransactionResponse] {
+	return services.InTransaction(ctx, uc.uow, inventoryTxOptions, func(ctx context.Context) Result[*dto.TransactionResponse] {
+		findResult := uc.transactionRepo.LockSellTransactionById(ctx, id)
+		if findResult.IsError() && !errors.Is(findResult, ENTITY_NOT_FOUND) {
+			return Wrap[*dto.TransactionResponse](findResult, "Failed to find sell transaction").WithMessage(i18n.TRANSACTION_CANCEL_FAILED)
+		}
+		if findResult.IsError() {
+			return NewError[*dto.TransactionResponse](
+				fmt.Sprintf("Sell transaction with id %d not found", id),
+				true,
+			).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.SELL_TRANSACTION_NOT_FOUND, id)
+		}
+
+		existingTransaction := findResult.Value()
+		if existingTransaction.CollectorId != collectorId {
+			return NewError[*dto.TransactionResponse](
+				"You are not authorized to cancel this transaction",
+				true,
+			).WithCause(FORBIDDEN_ERROR).WithMessage(i18n.TRANSACTION_CANCEL_FORBIDDEN)
+		}
+
+		if existingTransaction.IsCancelled() {
+			return NewError[*dto.TransactionResponse]("Transaction already cancelled", true).WithCause(ENTITY_DUPLICATE).WithMessage(i18n.TRANSACTION_ALREADY_CANCELLED)
+		}
+
+		// stok dikurangi oleh trigger pembatalan, trigger menolak jika minyaknya sudah terlanjur didistribusikan
+		result := uc.transactionRepo.CancelSellTransaction(ctx, id, reason)
+		if e := result.RootError(); e != nil {
+			if e.Cause() == BAD_REQUEST_ERROR {
+				return NewError[*dto.TransactionResponse](
+					"Cannot cancel transaction, collector oil inventory would become negative",
+					true,
+				).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.TRANSACTION_CANCEL_NEGATIVE_INVENTORY)
+			}
+
+			return Wrap[*dto.TransactionResponse](result, "Failed to cancel sell transaction").WithMessage(i18n.TRANSACTION_CANCEL_FAILED)
+		}
+
+		transaction := result.Value()
+		response := &dto.TransactionResponse{
+			Id:              transaction.Id,
+			SellerId:        transaction.SellerId,
+			OilVolume:       transaction.Volume,
+			Price:           transaction.Price,
+			TransactionType: dto.TRANSACTION_SELL,
+			CancelledAt:     transaction.CancelledAt,
+			CancelReason:    transaction.CancelReason,
+			CreatedAt:       transaction.CreatedAt,
+			UpdatedAt:       transaction.UpdatedAt,
+		}
+
+		return Ok(response)
+	})
+}
+
+func (uc *TransactionUsecase) cancelDistributeTransaction(ctx context.Context, collectorId int64, id int64, reason string) Result[*dto.TransactionResponse] {
+	return services.InTransaction(ctx, uc.uow, inventoryTxOptions, func(ctx context.Context) Result[*dto.TransactionResponse] {
+		findResult := uc.transactionRepo.LockDistributeTransactionById(ctx, id)
+		if findResult.IsError() && !errors.Is(findResult, ENTITY_NOT_FOUND) {
+			return Wrap[*dto.TransactionResponse](findResult, "Failed to find distribute transaction").WithMessage(i18n.TRANSACTION_CANCEL_FAILED)
+		}
+		if findResult.IsError() {
+			return NewError[*dto.TransactionResponse](
+				fmt.Sprintf("Distribute transaction with id %d not found", id),
+				true,
+			).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.DISTRIBUTE_TRANSACTION_NOT_FOUND, id)
+		}
+
+		existingTransaction := findResult.Value()
+		if existingTransaction.CollectorId != collectorId {
+			return NewError[*dto.TransactionResponse](
+				"You are not authorized to cancel this transaction",
+				true,
+			).WithCause(FORBIDDEN_ERROR).WithMessage(i18n.TRANSACTION_CANCEL_FORBIDDEN)
+		}
+
+		if existingTransaction.IsCancelled() {
+			return NewError[*dto.TransactionResponse]("Transaction already cancelled", true).WithCause(ENTITY_DUPLICATE).WithMessage(i18n.TRANSACTION_ALREADY_CANCELLED)
+		}
+
+		result := uc.transactionRepo.CancelDistributeTransaction(ctx, id, reason)
+		if result.IsError() {
+			return Wrap[*dto.TransactionResponse](result, "Failed to cancel distribute transaction").WithMessage(i18n.TRANSACTION_CANCEL_FAILED)
+		}
+
+		transaction := result.Value()
+		response := &dto.TransactionResponse{
+			Id:              transaction.Id,
+			CompanyId:       transaction.CompanyId,
+			OilVolume:       transaction.Volume,
+			Price:           transaction.Price,
+			TransactionType: dto.TRANSACTION_BUY,
+			CancelledAt:     transaction.CancelledAt,
+			CancelReason:    transaction.CancelReason,
+			CreatedAt:       transaction.CreatedAt,
+			UpdatedAt:       transaction.UpdatedAt,
+		}
+
+		return Ok(response)
+	})
+}
+
+func (uc *TransactionUsecase) SyncTransactions(ctx context.Context, collectorId int64, req *dto.SyncTransactionRequest) Result[*dto.SyncTransactionResponse] {
+	response := &dto.SyncTransactionResponse{Items: make([]dto.SyncItemResult, 0, len(req.Items))}
+
+	for i := range req.Items {
+		item := uc.syncTransaction(ctx, collectorId, &req.Items[i])
+
+		switch item.Status {
+		case dto.SYNC_CREATED:
+			response.Created++
+			uc.recordCreated(item.Transaction)
+		case dto.SYNC_DUPLICATE:
+			response.Duplicate++
+		default:
+			response.Rejected++
+		}
+		response.Items = append(response.Items, item)
 	}
 
 	return Ok(response)
 }
 
-func (uc *TransactionUsecase) updateDistributeTransaction(ctx context.Context, collectorId int64, id int64, updateDto *dto.UpdateTransactionDto) Result[*dto.TransactionResponse] {
-	// First, verify the transaction exists
-	findResult := uc.transactionRepo.FindDistributeTransactionById(ctx, id)
-	if findResult.IsError() {
-		return NewError[*dto.TransactionResponse](
-			fmt.Sprintf("Distribute transaction with id %d not found", id),
-			true,
-		).WithCause(ENTITY_NOT_FOUND)
+func (uc *TransactionUsecase) syncTransaction(ctx context.Context, collectorId int64, item *dto.SyncTransactionItem) dto.SyncItemResult {
+	rejected := func(code string, reason string) dto.SyncItemResult {
+		logger.FromContext(ctx).Warn("sync item rejected", "client_id", item.ClientId, "reason_code", code, "reason", reason)
+		return dto.SyncItemResult{ClientId: item.ClientId, Status: dto.SYNC_REJECTED, ReasonCode: code, Reason: reason}
 	}
 
-	// Verify the transaction belongs to this collector
-	existingTransaction := findResult.Value()
-	if existingTransaction.CollectorId != collectorId {
-		return NewError[*dto.TransactionResponse](
-			"You are not authorized to update this transaction",
-			true,
-		).WithCause(CREDENTIALS_ERROR)
+	// beberapa perangkat mengirim UUID dengan huruf besar
+	item.ClientId = strings.ToLower(item.ClientId)
+
+	if err := validator.Validate(item); err != nil {
+		return rejected(i18n.VALIDATION_FAILED, err.Error())
+	}
+	if item.ClientTimestamp.After(time.Now().Add(dto.SYNC_CLOCK_SKEW)) {
+		return rejected(i18n.CLIENT_TIMESTAMP_IN_FUTURE, "client_timestamp must not be in the future")
 	}
 
-	// Update the transaction
-	result := uc.transactionRepo.UpdateDistributeTransaction(ctx, id, updateDto.OilVolume, updateDto.Price)
+	result := services.InTransaction(ctx, uc.uow, inventoryTxOptions, func(ctx context.Context) Result[dto.SyncItemResult] {
+		claimed := uc.syncRepo.Claim(ctx, collectorId, item.ClientId, string(item.TransactionType), item.ClientTimestamp)
+		if claimed.IsError() {
+			return Wrap[dto.SyncItemResult](claimed, "Failed to record synced transaction").WithMessage(i18n.SYNCED_TRANSACTION_FAILED)
+		}
+
+		if !claimed.Value() {
+			return uc.syncedDuplicate(ctx, collectorId, item.ClientId)
+		}
+
+		var created Result[*dto.TransactionResponse]
+		if item.TransactionType == dto.TRANSACTION_SELL {
+			created = uc.createSellTransaction(ctx, collectorId, &item.TransactionCreateDto, item.ClientTimestamp)
+		} else {
+			// stok tetap dicek oleh trigger update_oil_on_distribute, urutan item menentukan stok yang tersedia
+			created = uc.createDistributeTransaction(ctx, collectorId, &item.TransactionCreateDto, item.ClientTimestamp)
+		}
+		if e := created.RootError(); e != nil {
+			return ErrorFrom[dto.SyncItemResult](e)
+		}
+		transaction := created.Value()
+
+		saved := uc.syncRepo.SetTransactionId(ctx, collectorId, item.ClientId, transaction.Id)
+		if saved.IsError() {
+			return Wrap[dto.SyncItemResult](saved, "Failed to record synced transaction").WithMessage(i18n.SYNCED_TRANSACTION_FAILED)
+		}
+
+		return Ok(dto.SyncItemResult{
+			ClientId:        item.ClientId,
+			Status:          dto.SYNC_CREATED,
+			TransactionId:   transaction.Id,
+			TransactionType: transaction.TransactionType,
+			Transaction:     transaction,
+		})
+	})
+
 	if result.IsError() {
-		log.Println(result.Error())
-		return NewError[*dto.TransactionResponse](
-			"Failed to update distribute transaction",
-			true,
-		).WithCause(INTERNAL_SERVICE_ERROR)
+		if e := result.ExpectedError(); e != nil {
+			code := e.MessageId()
+			if code == "" {
+				code = e.Cause().Code()
+			}
+			return rejected(code, e.Error())
+		}
+
+		// error item sync tidak diteruskan ke ErrorHandler, jadi dicatat di sini
+		logger.FromContext(ctx).Error("failed to sync transaction", "error", result)
+		code := result.LastError().MessageId()
+		if code == "" {
+			code = i18n.TRANSACTION_CREATE_FAILED
+		}
+		return rejected(code, "Failed to sync transaction")
 	}
 
-	transaction := result.Value()
-	response := &dto.TransactionResponse{
-		Id:              transaction.Id,
-		CompanyId:       transaction.CompanyId,
-		OilVolume:       transaction.Volume,
-		Price:           transaction.Price,
-		TransactionType: dto.TRANSACTION_BUY,
-		CreatedAt:       transaction.CreatedAt,
-		UpdatedAt:       transaction.UpdatedAt,
+	return result.Value()
+}
+
+func (uc *TransactionUsecase) syncedDuplicate(ctx context.Context, collectorId int64, clientId string) Result[dto.SyncItemResult] {
+	found := uc.syncRepo.Find(ctx, collectorId, clientId)
+	if found.IsError() {
+		return Wrap[dto.SyncItemResult](found, "Failed to get synced transaction").WithMessage(i18n.SYNCED_TRANSACTION_FAILED)
 	}
+	synced := found.Value()
 
-	return Ok(response)
+	item := dto.SyncItemResult{
+		ClientId:        clientId,
+		Status:          dto.SYNC_DUPLICATE,
+		TransactionType: dto.TransactionType(synced.TransactionType),
+	}
+	if synced.TransactionId != nil {
+		item.TransactionId = *synced.TransactionId
+	}
+
+	return Ok(item)
 }
@@ -0,0 +1,654 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/metrics"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const testCollectorId int64 = 7
+
+// fakeLockLog mencatat urutan baris yang dikunci, kunci di luar transaction ditandai
+type fakeLockLog struct {
+	locks []string
+}
+
+func (l *fakeLockLog) lock(ctx context.Context, name string) {
+	if ctx.Value(fakeTxKey{}) == nil {
+		name += " outside transaction"
+	}
+	l.locks = append(l.locks, name)
+}
+
+type fakeOilRepository struct {
+	repository.IOilRepository
+	log     *fakeLockLog
+	volumes map[int64]float64
+}
+
+func (r *fakeOilRepository) snapshot() func() {
+	saved := maps.Clone(r.volumes)
+	return func() { r.volumes = saved }
+}
+
+// add meniru trigger stok di database, ditolak jika stok menjadi negatif
+func (r *fakeOilRepository) add(collectorId int64, delta float64) bool {
+	if r.volumes[collectorId]+delta < 0 {
+		return false
+	}
+	r.volumes[collectorId] += delta
+	return true
+}
+
+func (r *fakeOilRepository) AdjustVolume(ctx context.Context, collectorId int64, added, removed float64) Result[float64] {
+	r.log.lock(ctx, fmt.Sprintf("oil:%d", collectorId))
+
+	if !r.add(collectorId, added-removed) {
+		return NewError[float64]("insufficient oil inventory", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INSUFFICIENT_OIL_INVENTORY)
+	}
+	return Ok(r.volumes[collectorId])
+}
+
+// fakeTransactionRepository menyimpan transaksi di memory dan mengubah stok seperti trigger pembatalan
+type fakeTransactionRepository struct {
+	repository.ITransactionRepository
+	log         *fakeLockLog
+	oil         *fakeOilRepository
+	sells       map[int64]entity.SellTransaction
+	distributes map[int64]entity.DistributeTransaction
+	nextId      int64
+	failCreate  bool
+	failUpdate  bool
+	failLock    bool
+}
+
+func (r *fakeTransactionRepository) snapshot() func() {
+	sells, distributes, nextId := maps.Clone(r.sells), maps.Clone(r.distributes), r.nextId
+	return func() { r.sells, r.distributes, r.nextId = sells, distributes, nextId }
+}
+
+func insufficientInventory[T any]() Result[T] {
+	return NewError[T]("insufficient oil inventory", true).WithCause(BAD_REQUEST_ERROR).WithMessage(i18n.INSUFFICIENT_OIL_INVENTORY)
+}
+
+func transactionNotFound[T any]() Result[T] {
+	return NewError[T]("transaction not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.TRANSACTION_NOT_FOUND)
+}
+
+func (r *fakeTransactionRepository) CreateSellTransaction(ctx context.Context, tx *entity.SellTransaction) Result[*entity.SellTransaction] {
+	if r.failCreate {
+		return Wrap[*entity.SellTransaction](errFakeDatabase, "database error")
+	}
+
+	tx.Id = r.nextId
+	r.nextId++
+	if tx.CreatedAt.IsZero() {
+		tx.CreatedAt = time.Now()
+	}
+	r.oil.add(tx.CollectorId, tx.Volume)
+	r.sells[tx.Id] = *tx
+	return Ok(tx)
+}
+
+func (r *fakeTransactionRepository) CreateDistributeTransaction(ctx context.Context, tx *entity.DistributeTransaction) Result[*entity.DistributeTransaction] {
+	if r.failCreate {
+		return Wrap[*entity.DistributeTransaction](errFakeDatabase, "database error")
+	}
+	if !r.oil.add(tx.CollectorId, -tx.Volume) {
+		return insufficientInventory[*entity.DistributeTransaction]()
+	}
+
+	tx.Id = r.nextId
+	r.nextId++
+	if tx.CreatedAt.IsZero() {
+		tx.CreatedAt = time.Now()
+	}
+	r.distributes[tx.Id] = *tx
+	return Ok(tx)
+}
+
+func (r *fakeTransactionRepository) LockSellTransactionById(ctx context.Context, id int64) Result[*entity.SellTransaction] {
+	r.log.lock(ctx, fmt.Sprintf("sell_transaction:%d", id))
+	if r.failLock {
+		return Wrap[*entity.SellTransaction](errFakeDatabase, "database error")
+	}
+
+	tx, ok := r.sells[id]
+	if !ok {
+		return transactionNotFound[*entity.SellTransaction]()
+	}
+	return Ok(&tx)
+}
+
+func (r *fakeTransactionRepository) LockDistributeTransactionById(ctx context.Context, id int64) Result[*entity.DistributeTransaction] {
+	r.log.lock(ctx, fmt.Sprintf("distribute_transaction:%d", id))
+	if r.failLock {
+		return Wrap[*entity.DistributeTransaction](errFakeDatabase, "database error")
+	}
+
+	tx, ok := r.distributes[id]
+	if !ok {
+		return transactionNotFound[*entity.DistributeTransaction]()
+	}
+	return Ok(&tx)
+}
+
+func (r *fakeTransactionRepository) UpdateSellTransaction(ctx context.Context, id int64, volume float64, price float64) Result[*entity.SellTransaction] {
+	if r.failUpdate {
+		return Wrap[*entity.SellTransaction](errFakeDatabase, "database error")
+	}
+
+	tx := r.sells[id]
+	tx.Volume, tx.Price = volume, price
+	r.sells[id] = tx
+	return Ok(&tx)
+}
+
+func (r *fakeTransactionRepository) UpdateDistributeTransaction(ctx context.Context, id int64, volume float64, price float64) Result[*entity.DistributeTransaction] {
+	if r.failUpdate {
+		return Wrap[*entity.DistributeTransaction](errFakeDatabase, "database error")
+	}
+
+	tx := r.distributes[id]
+	tx.Volume, tx.Price = volume, price
+	r.distributes[id] = tx
+	return Ok(&tx)
+}
+
+func (r *fakeTransactionRepository) CancelSellTransaction(ctx context.Context, id int64, reason string) Result[*entity.SellTransaction] {
+	tx := r.sells[id]
+	if !r.oil.add(tx.CollectorId, -tx.Volume) {
+		return insufficientInventory[*entity.SellTransaction]()
+	}
+
+	now := time.Now()
+	tx.CancelledAt, tx.CancelReason = &now, &reason
+	r.sells[id] = tx
+	return Ok(&tx)
+}
+
+func (r *fakeTransactionRepository) CancelDistributeTransaction(ctx context.Context, id int64, reason string) Result[*entity.DistributeTransaction] {
+	tx := r.distributes[id]
+	r.oil.add(tx.CollectorId, tx.Volume)
+
+	now := time.Now()
+	tx.CancelledAt, tx.CancelReason = &now, &reason
+	r.distributes[id] = tx
+	return Ok(&tx)
+}
+
+type fakeIdempotencyKeyRepository struct {
+	repository.IIdempotencyKeyRepository
+	log       *fakeLockLog
+	keys      map[string]entity.IdempotencyKey
+	failClaim bool
+}
+
+func (r *fakeIdempotencyKeyRepository) snapshot() func() {
+	saved := maps.Clone(r.keys)
+	return func() { r.keys = saved }
+}
+
+func (r *fakeIdempotencyKeyRepository) Claim(ctx context.Context, collectorId int64, key string, requestHash string, expiresAt time.Time) Result[bool] {
+	r.log.lock(ctx, "idempotency_key:"+key)
+	if r.failClaim {
+		return Wrap[bool](errFakeDatabase, "database error")
+	}
+
+	id := fmt.Sprintf("%d:%s", collectorId, key)
+	if _, ok := r.keys[id]; ok {
+		return Ok(false)
+	}
+	r.keys[id] = entity.IdempotencyKey{CollectorId: collectorId, IdempotencyKey: key, RequestHash: requestHash, ExpiresAt: expiresAt}
+	return Ok(true)
+}
+
+func (r *fakeIdempotencyKeyRepository) Find(ctx context.Context, collectorId int64, key string) Result[*entity.IdempotencyKey] {
+	idempotencyKey, ok := r.keys[fmt.Sprintf("%d:%s", collectorId, key)]
+	if !ok {
+		return NewError[*entity.IdempotencyKey]("idempotency key not found", true).WithCause(ENTITY_NOT_FOUND)
+	}
+	return Ok(&idempotencyKey)
+}
+
+func (r *fakeIdempotencyKeyRepository) SaveResponse(ctx context.Context, collectorId int64, key string, responseBody []byte) Result[bool] {
+	id := fmt.Sprintf("%d:%s", collectorId, key)
+	idempotencyKey := r.keys[id]
+	idempotencyKey.ResponseBody = responseBody
+	r.keys[id] = idempotencyKey
+	return Ok(true)
+}
+
+type fakeSyncedTransactionRepository struct {
+	repository.ISyncedTransactionRepository
+	synced map[string]entity.SyncedTransaction
+}
+
+func (r *fakeSyncedTransactionRepository) snapshot() func() {
+	saved := maps.Clone(r.synced)
+	return func() { r.synced = saved }
+}
+
+func (r *fakeSyncedTransactionRepository) Claim(ctx context.Context, collectorId int64, clientId string, transactionType string, clientCreatedAt time.Time) Result[bool] {
+	id := fmt.Sprintf("%d:%s", collectorId, clientId)
+	if _, ok := r.synced[id]; ok {
+		return Ok(false)
+	}
+	r.synced[id] = entity.SyncedTransaction{CollectorId: collectorId, ClientId: clientId, TransactionType: transactionType, ClientCreatedAt: clientCreatedAt}
+	return Ok(true)
+}
+
+func (r *fakeSyncedTransactionRepository) Find(ctx context.Context, collectorId int64, clientId string) Result[*entity.SyncedTransaction] {
+	synced, ok := r.synced[fmt.Sprintf("%d:%s", collectorId, clientId)]
+	if !ok {
+		return NewError[*entity.SyncedTransaction]("synced transaction not found", true).WithCause(ENTITY_NOT_FOUND)
+	}
+	return Ok(&synced)
+}
+
+func (r *fakeSyncedTransactionRepository) SetTransactionId(ctx context.Context, collectorId int64, clientId string, transactionId int64) Result[bool] {
+	id := fmt.Sprintf("%d:%s", collectorId, clientId)
+	synced := r.synced[id]
+	synced.TransactionId = &transactionId
+	r.synced[id] = synced
+	return Ok(true)
+}
+
+type transactionFixture struct {
+	uc              *TransactionUsecase
+	transactionRepo *fakeTransactionRepository
+	oilRepo         *fakeOilRepository
+	idempotencyRepo *fakeIdempotencyKeyRepository
+	syncRepo        *fakeSyncedTransactionRepository
+	uow             *fakeUnitOfWork
+	log             *fakeLockLog
+	metrics         *metrics.Metrics
+}
+
+func setupTransactionUsecase(stock float64) *transactionFixture {
+	log := &fakeLockLog{}
+	oilRepo := &fakeOilRepository{log: log, volumes: map[int64]float64{testCollectorId: stock}}
+	transactionRepo := &fakeTransactionRepository{
+		log:         log,
+		oil:         oilRepo,
+		sells:       map[int64]entity.SellTransaction{},
+		distributes: map[int64]entity.DistributeTransaction{},
+		nextId:      1,
+	}
+	idempotencyRepo := &fakeIdempotencyKeyRepository{log: log, keys: map[string]entity.IdempotencyKey{}}
+	userRepo := &fakeUserRepository{
+		sellers:   map[string]*entity.UserWithSeller{"seller@example.com": {SellerId: 2}},
+		companies: map[string]*entity.UserWithCompany{"company@example.com": {CompanyId: 3}},
+	}
+	syncRepo := &fakeSyncedTransactionRepository{synced: map[string]entity.SyncedTransaction{}}
+	uow := &fakeUnitOfWork{participants: []txParticipant{oilRepo, transactionRepo, idempotencyRepo, syncRepo}}
+	m := metrics.New()
+
+	uc := &TransactionUsecase{
+		transactionRepo: transactionRepo,
+		userRepo:        userRepo,
+		oilRepo:         oilRepo,
+		idempotencyRepo: idempotencyRepo,
+		syncRepo:        syncRepo,
+		uow:             uow,
+		metrics:         m,
+		cfg:             &config.Config{IDEMPOTENCY_KEY_TTL: time.Hour},
+	}
+
+	return &transactionFixture{uc, transactionRepo, oilRepo, idempotencyRepo, syncRepo, uow, log, m}
+}
+
+func (f *transactionFixture) addSell(volume float64) int64 {
+	id := f.transactionRepo.nextId
+	f.transactionRepo.nextId++
+	f.transactionRepo.sells[id] = entity.SellTransaction{Id: id, SellerId: 2, CollectorId: testCollectorId, Volume: volume, Price: 1000}
+	return id
+}
+
+func (f *transactionFixture) addDistribute(volume float64) int64 {
+	id := f.transactionRepo.nextId
+	f.transactionRepo.nextId++
+	f.transactionRepo.distributes[id] = entity.DistributeTransaction{Id: id, CompanyId: 3, CollectorId: testCollectorId, Volume: volume, Price: 2000}
+	return id
+}
+
+func (f *transactionFixture) stock() float64 {
+	return f.oilRepo.volumes[testCollectorId]
+}
+
+func TestUpdateSellTransaction_AdjustsInventory(t *testing.T) {
+	g := NewWithT(t)
+	// stok 10 berasal dari pembelian 10 liter
+	f := setupTransactionUsecase(10)
+	id := f.addSell(10)
+
+	result := f.uc.UpdateTransaction(context.Background(), testCollectorId, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_SELL, OilVolume: 4, Price: 1000})
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().OilVolume).To(Equal(4.0))
+	g.Expect(f.stock()).To(Equal(4.0))
+	g.Expect(f.log.locks).To(Equal([]string{fmt.Sprintf("sell_transaction:%d", id), "oil:7"}))
+	g.Expect(f.uow.commits).To(Equal(1))
+}
+
+func TestUpdateSellTransaction_DecreaseBelowStockRollsBack(t *testing.T) {
+	g := NewWithT(t)
+	// 10 liter dibeli lalu 8 liter sudah didistribusikan
+	f := setupTransactionUsecase(2)
+	id := f.addSell(10)
+
+	result := f.uc.UpdateTransaction(context.Background(), testCollectorId, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_SELL, OilVolume: 5, Price: 1000})
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(errors.Is(result, BAD_REQUEST_ERROR)).To(BeTrue())
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.TRANSACTION_UPDATE_NEGATIVE_INVENTORY))
+	g.Expect(f.stock()).To(Equal(2.0))
+	g.Expect(f.transactionRepo.sells[id].Volume).To(Equal(10.0))
+	g.Expect(f.uow.rollbacks).To(Equal(1))
+}
+
+func TestUpdateDistributeTransaction_AdjustsInventory(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(5)
+	id := f.addDistribute(5)
+
+	result := f.uc.UpdateTransaction(context.Background(), testCollectorId, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_BUY, OilVolume: 8, Price: 2000})
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().CompanyId).To(Equal(int64(3)))
+	g.Expect(f.stock()).To(Equal(2.0))
+	g.Expect(f.log.locks).To(Equal([]string{fmt.Sprintf("distribute_transaction:%d", id), "oil:7"}))
+
+	result = f.uc.UpdateTransaction(context.Background(), testCollectorId, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_BUY, OilVolume: 2, Price: 2000})
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(f.stock()).To(Equal(8.0))
+}
+
+func TestUpdateDistributeTransaction_IncreaseBeyondStockRollsBack(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(5)
+	id := f.addDistribute(5)
+
+	result := f.uc.UpdateTransaction(context.Background(), testCollectorId, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_BUY, OilVolume: 11, Price: 2000})
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.TRANSACTION_UPDATE_NEGATIVE_INVENTORY))
+	g.Expect(f.stock()).To(Equal(5.0))
+	g.Expect(f.transactionRepo.distributes[id].Volume).To(Equal(5.0))
+	g.Expect(f.uow.rollbacks).To(Equal(1))
+}
+
+func TestUpdateTransaction_FailedUpdateRestoresInventory(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(10)
+	id := f.addSell(10)
+	f.transactionRepo.failUpdate = true
+
+	result := f.uc.UpdateTransaction(context.Background(), testCollectorId, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_SELL, OilVolume: 15, Price: 1000})
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError()).To(BeNil())
+	g.Expect(result.LastError().MessageId()).To(Equal(i18n.TRANSACTION_UPDATE_FAILED))
+	g.Expect(f.stock()).To(Equal(10.0))
+	g.Expect(f.uow.rollbacks).To(Equal(1))
+}
+
+func TestUpdateTransaction_LockFailureIsUnexpected(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(0)
+	id := f.addDistribute(5)
+	f.transactionRepo.failLock = true
+
+	result := f.uc.UpdateTransaction(context.Background(), testCollectorId, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_BUY, OilVolume: 4, Price: 1000})
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError()).To(BeNil())
+	g.Expect(errors.Is(result, ENTITY_NOT_FOUND)).To(BeFalse())
+	g.Expect(result.LastError().MessageId()).To(Equal(i18n.TRANSACTION_UPDATE_FAILED))
+}
+
+func TestUpdateTransaction_RejectsOtherTypeAndCollector(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(10)
+	id := f.addSell(10)
+
+	// id transaksi SELL tidak bisa diubah sebagai BUY
+	result := f.uc.UpdateTransaction(context.Background(), testCollectorId, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_BUY, OilVolume: 4, Price: 1000})
+
+	g.Expect(errors.Is(result, ENTITY_NOT_FOUND)).To(BeTrue())
+	g.Expect(f.transactionRepo.sells[id].Volume).To(Equal(10.0))
+
+	result = f.uc.UpdateTransaction(context.Background(), testCollectorId+1, id, &dto.UpdateTransactionDto{TransactionType: dto.TRANSACTION_SELL, OilVolume: 4, Price: 1000})
+
+	g.Expect(errors.Is(result, FORBIDDEN_ERROR)).To(BeTrue())
+	g.Expect(f.stock()).To(Equal(10.0))
+	g.Expect(f.log.locks).ToNot(ContainElement(HavePrefix("oil:")))
+	g.Expect(f.uow.rollbacks).To(Equal(2))
+}
+
+func TestCancelSellTransaction_DistributedOilRollsBack(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(3)
+	id := f.addSell(10)
+
+	result := f.uc.CancelTransaction(context.Background(), testCollectorId, id, &dto.CancelTransactionDto{TransactionType: dto.TRANSACTION_SELL, Reason: "wrong seller"})
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.TRANSACTION_CANCEL_NEGATIVE_INVENTORY))
+	g.Expect(f.transactionRepo.sells[id].CancelledAt).To(BeNil())
+	g.Expect(f.stock()).To(Equal(3.0))
+	g.Expect(f.uow.rollbacks).To(Equal(1))
+}
+
+func TestCancelDistributeTransaction_RestoresInventory(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(0)
+	id := f.addDistribute(5)
+
+	result := f.uc.CancelTransaction(context.Background(), testCollectorId, id, &dto.CancelTransactionDto{TransactionType: dto.TRANSACTION_BUY, Reason: "  company rejected  "})
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(*result.Value().CancelReason).To(Equal("company rejected"))
+	g.Expect(f.stock()).To(Equal(5.0))
+	g.Expect(f.log.locks).To(Equal([]string{fmt.Sprintf("distribute_transaction:%d", id)}))
+
+	result = f.uc.CancelTransaction(context.Background(), testCollectorId, id, &dto.CancelTransactionDto{TransactionType: dto.TRANSACTION_BUY, Reason: "again"})
+
+	g.Expect(errors.Is(result, ENTITY_DUPLICATE)).To(BeTrue())
+	g.Expect(f.stock()).To(Equal(5.0))
+}
+
+func TestCancelTransaction_LockFailureIsUnexpected(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(10)
+	id := f.addSell(5)
+	f.transactionRepo.failLock = true
+
+	result := f.uc.CancelTransaction(context.Background(), testCollectorId, id, &dto.CancelTransactionDto{TransactionType: dto.TRANSACTION_SELL, Reason: "wrong seller"})
+
+	// kegagalan database tidak boleh dilaporkan sebagai 404
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError()).To(BeNil())
+	g.Expect(errors.Is(result, ENTITY_NOT_FOUND)).To(BeFalse())
+	g.Expect(result.LastError().MessageId()).To(Equal(i18n.TRANSACTION_CANCEL_FAILED))
+}
+
+func distributeDto(volume float64) *dto.TransactionCreateDto {
+	return &dto.TransactionCreateDto{Email: "company@example.com", OilVolume: volume, Price: 2000, TransactionType: dto.TRANSACTION_BUY}
+}
+
+func TestCreateTransactionIdempotent_Replay(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(10)
+
+	first := f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId, "key-1", distributeDto(4))
+
+	g.Expect(first.IsError()).To(BeFalse())
+	g.Expect(first.Value().Replayed).To(BeFalse())
+	g.Expect(f.log.locks).To(Equal([]string{"idempotency_key:key-1"}))
+	g.Expect(f.stock()).To(Equal(6.0))
+
+	replayed := f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId, "key-1", distributeDto(4))
+
+	g.Expect(replayed.IsError()).To(BeFalse())
+	g.Expect(replayed.Value().Replayed).To(BeTrue())
+	g.Expect(replayed.Value().Value.Id).To(Equal(first.Value().Value.Id))
+	g.Expect(f.transactionRepo.distributes).To(HaveLen(1))
+	g.Expect(f.stock()).To(Equal(6.0))
+
+	// key yang sama milik collector lain tidak dianggap replay
+	other := f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId+1, "key-1", &dto.TransactionCreateDto{Email: "seller@example.com", OilVolume: 1, Price: 1000, TransactionType: dto.TRANSACTION_SELL})
+
+	g.Expect(other.IsError()).To(BeFalse())
+	g.Expect(other.Value().Replayed).To(BeFalse())
+}
+
+func TestCreateTransactionIdempotent_HashMismatch(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(10)
+
+	g.Expect(f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId, "key-1", distributeDto(4)).IsError()).To(BeFalse())
+
+	result := f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId, "key-1", distributeDto(5))
+
+	g.Expect(errors.Is(result, UNPROCESSABLE_ENTITY_ERROR)).To(BeTrue())
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.IDEMPOTENCY_KEY_MISMATCH))
+	g.Expect(f.transactionRepo.distributes).To(HaveLen(1))
+}
+
+func TestCreateTransactionIdempotent_InProgress(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(10)
+	hash, _ := hashRequest(distributeDto(4))
+	f.idempotencyRepo.keys["7:key-1"] = entity.IdempotencyKey{CollectorId: testCollectorId, IdempotencyKey: "key-1", RequestHash: hash}
+
+	result := f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId, "key-1", distributeDto(4))
+
+	g.Expect(errors.Is(result, ENTITY_DUPLICATE)).To(BeTrue())
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.IDEMPOTENCY_KEY_IN_PROGRESS))
+	g.Expect(f.transactionRepo.distributes).To(BeEmpty())
+}
+
+func TestCreateTransactionIdempotent_ClaimFailureIsUnexpected(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(10)
+	f.idempotencyRepo.failClaim = true
+
+	result := f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId, "key-1", distributeDto(4))
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError()).To(BeNil())
+	g.Expect(result.LastError().MessageId()).To(Equal(i18n.IDEMPOTENCY_KEY_FAILED))
+	g.Expect(f.transactionRepo.distributes).To(BeEmpty())
+}
+
+func TestCreateTransactionIdempotent_FailedCreateReleasesKey(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(10)
+	f.transactionRepo.failCreate = true
+
+	result := f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId, "key-1", distributeDto(4))
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(f.idempotencyRepo.keys).To(BeEmpty())
+	g.Expect(f.uow.rollbacks).To(Equal(1))
+
+	// retry dengan key yang sama membuat transaksi seperti request baru
+	f.transactionRepo.failCreate = false
+	result = f.uc.CreateTransactionIdempotent(context.Background(), testCollectorId, "key-1", distributeDto(4))
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().Replayed).To(BeFalse())
+	g.Expect(f.transactionRepo.distributes).To(HaveLen(1))
+	g.Expect(f.idempotencyRepo.keys["7:key-1"].ResponseBody).ToNot(BeNil())
+}
+
+func TestCreateTransaction_InsufficientInventoryIsClientError(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(3)
+
+	result := f.uc.CreateTransaction(context.Background(), testCollectorId, distributeDto(4))
+
+	g.Expect(errors.Is(result, BAD_REQUEST_ERROR)).To(BeTrue())
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.INSUFFICIENT_OIL_INVENTORY))
+	g.Expect(f.stock()).To(Equal(3.0))
+	g.Expect(testutil.GatherAndCompare(f.metrics.Registry, strings.NewReader(`
+# HELP oil_bank_distribution_insufficient_inventory_total Distributions rejected because the collector inventory was insufficient.
+# TYPE oil_bank_distribution_insufficient_inventory_total counter
+oil_bank_distribution_insufficient_inventory_total 1
+`), "oil_bank_distribution_insufficient_inventory_total")).To(Succeed())
+
+	f.transactionRepo.failCreate = true
+	result = f.uc.CreateTransaction(context.Background(), testCollectorId, distributeDto(1))
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError()).To(BeNil())
+	g.Expect(result.LastError().MessageId()).To(Equal(i18n.TRANSACTION_CREATE_FAILED))
+}
+
+func TestSyncTransactions_ItemOutcomes(t *testing.T) {
+	g := NewWithT(t)
+	f := setupTransactionUsecase(5)
+	soldAt := time.Date(2025, 11, 24, 8, 30, 0, 0, time.UTC)
+
+	sell := dto.SyncTransactionItem{
+		TransactionCreateDto: dto.TransactionCreateDto{Email: "seller@example.com", OilVolume: 3, Price: 1000, TransactionType: dto.TRANSACTION_SELL},
+		ClientId:             "0b9c3f4e-7d5a-4c1b-9e2f-1a2b3c4d5e6f",
+		ClientTimestamp:      soldAt,
+	}
+	tooMuch := dto.SyncTransactionItem{
+		TransactionCreateDto: *distributeDto(10),
+		ClientId:             "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+		ClientTimestamp:      soldAt.Add(time.Hour),
+	}
+	distribute := dto.SyncTransactionItem{
+		TransactionCreateDto: *distributeDto(6),
+		ClientId:             "2d3e4f5a-6b7c-4d8e-9f0a-1b2c3d4e5f6a",
+		ClientTimestamp:      soldAt.Add(2 * time.Hour),
+	}
+	future := distribute
+	future.ClientId = "3e4f5a6b-7c8d-4e9f-0a1b-2c3d4e5f6a7b"
+	future.ClientTimestamp = time.Now().Add(time.Hour)
+	duplicate := sell
+	duplicate.ClientId = "0B9C3F4E-7D5A-4C1B-9E2F-1A2B3C4D5E6F"
+
+	result := f.uc.SyncTransactions(context.Background(), testCollectorId, &dto.SyncTransactionRequest{
+		Items: []dto.SyncTransactionItem{sell, tooMuch, distribute, future, duplicate},
+	})
+
+	g.Expect(result.IsError()).To(BeFalse())
+	response := result.Value()
+	g.Expect(response.Created).To(Equal(2))
+	g.Expect(response.Duplicate).To(Equal(1))
+	g.Expect(response.Rejected).To(Equal(2))
+
+	items := response.Items
+	g.Expect(items[0].Status).To(Equal(dto.SYNC_CREATED))
+	g.Expect(items[0].Transaction.CreatedAt).To(Equal(soldAt))
+	g.Expect(items[1].Status).To(Equal(dto.SYNC_REJECTED))
+	g.Expect(items[1].ReasonCode).To(Equal(i18n.INSUFFICIENT_OIL_INVENTORY))
+	g.Expect(items[2].Status).To(Equal(dto.SYNC_CREATED))
+	g.Expect(f.transactionRepo.distributes[items[2].TransactionId].CreatedAt).To(Equal(distribute.ClientTimestamp))
+	g.Expect(items[3].ReasonCode).To(Equal(i18n.CLIENT_TIMESTAMP_IN_FUTURE))
+	g.Expect(items[4].Status).To(Equal(dto.SYNC_DUPLICATE))
+	g.Expect(items[4].TransactionId).To(Equal(items[0].TransactionId))
+
+	// item yang ditolak tidak diklaim sehingga bisa dikirim ulang
+	g.Expect(f.syncRepo.synced).To(HaveLen(2))
+	g.Expect(f.stock()).To(Equal(2.0))
+}
@@ -2,38 +2,64 @@ package usecase
 
 import (
 	"context"
-	"log"
+	"errors"
+	"math"
+	"sync"
+	"time"
 
 	"github.com/crazydw4rf/oil-bank-backend/internal/auth"
 	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
 	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/logger"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/metrics"
 	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
 	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services"
 	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// LOGIN_RETRY_AFTER_FIELD adalah field error lockout login berisi sisa waktu lockout dalam detik
+const LOGIN_RETRY_AFTER_FIELD = "retry_after"
+
+// dummyPasswordHash dibuat dengan cost yang sama seperti hash password user
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte(uuid.NewString()), bcrypt.DefaultCost)
+	return hash
+})
+
 type IUserUsecase interface {
 	UserRegister(ctx context.Context, dto *dto.UserCreateRequest) Result[*entity.User]
-	UserLogin(ctx context.Context, dto *dto.UserLoginRequest) Result[*entity.UserWithCollector]
+	UserLogin(ctx context.Context, ip string, dto *dto.UserLoginRequest) Result[*entity.UserWithProfile]
+	UserUnlock(ctx context.Context, dto *dto.UserUnlockRequest) Result[bool]
 	UserUpdate(ctx context.Context, dto *dto.UserUpdateRequest) Result[*entity.User]
 	UserDelete(ctx context.Context, id int64) Result[bool]
 	UserFind(ctx context.Context, id int64) Result[*entity.User]
+	UserRefreshToken(ctx context.Context, userId int64, tokenId string) Result[*entity.UserWithProfile]
+	UserLogout(ctx context.Context, session *dto.UserSession) Result[bool]
+	UserLogoutAll(ctx context.Context, session *dto.UserSession) Result[bool]
 }
 
 type UserUsecase struct {
-	userRepo repository.IUserRepository
-	cfg      *config.Config
+	userRepo    repository.IUserRepository
+	tokenRepo   repository.IRefreshTokenRepository
+	revocations auth.RevocationStore
+	uow         services.UnitOfWork
+	throttle    *auth.LoginThrottle
+	keys        *auth.AccessTokenKeys
+	metrics     *metrics.Metrics
+	cfg         *config.Config
 }
 
-func NewUserUsecase(userRepo repository.IUserRepository, cfg *config.Config) IUserUsecase {
-	return UserUsecase{userRepo, cfg}
+func NewUserUsecase(userRepo repository.IUserRepository, tokenRepo repository.IRefreshTokenRepository, revocations auth.RevocationStore, uow services.UnitOfWork, throttle *auth.LoginThrottle, keys *auth.AccessTokenKeys, metrics *metrics.Metrics, cfg *config.Config) IUserUsecase {
+	return UserUsecase{userRepo, tokenRepo, revocations, uow, throttle, keys, metrics, cfg}
 }
 
 var _ IUserUsecase = (*UserUsecase)(nil)
 
 func (uc UserUsecase) UserRegister(ctx context.Context, dto *dto.UserCreateRequest) Result[*entity.User] {
-	// TODO: implementasi validasi dto dengan menggunakan library validator
 	user := &entity.User{
 		Username: dto.Username,
 		Email:    dto.Email,
@@ -43,42 +69,99 @@ func (uc UserUsecase) UserRegister(ctx context.Context, dto *dto.UserCreateReque
 	hash, err := bcrypt.GenerateFromPassword([]byte(dto.Password), bcrypt.DefaultCost)
 	if err != nil {
 		if err == bcrypt.ErrMismatchedHashAndPassword {
-			return NewError[*entity.User]("Password salah", true).WithCause(CREDENTIALS_ERROR)
+			return NewError[*entity.User]("Wrong password", true).WithCause(CREDENTIALS_ERROR).WithMessage(i18n.PASSWORD_INVALID)
 		}
 
-		return NewError[*entity.User]("Password hashing gagal").WithCause(UNKNOWN_ERROR)
+		return NewError[*entity.User]("Failed to hash password").WithCause(UNKNOWN_ERROR)
 	}
 	user.PasswordHash = string(hash)
 
 	result := uc.userRepo.Create(ctx, user)
 	if result.IsError() {
-		log.Println(result.Error())
-		return Err(result, "Gagal membuat user baru", true)
+		logger.FromContext(ctx).Error("failed to create user", "error", result)
+		return Err(result, "Failed to create user", true).WithMessage(i18n.USER_CREATE_FAILED)
 	}
 
 	return Ok(user)
 }
 
-func (uc UserUsecase) UserLogin(ctx context.Context, dto *dto.UserLoginRequest) Result[*entity.UserWithCollector] {
-	result := uc.userRepo.FindByEmailWithCollector(ctx, dto.Email)
-	if result.IsError() {
-		return Err(result, "Email tidak ditemukan", true)
+func (uc UserUsecase) UserLogin(ctx context.Context, ip string, dto *dto.UserLoginRequest) Result[*entity.UserWithProfile] {
+	locked := uc.throttle.Check(ctx, dto.Email, ip)
+	if locked.IsError() {
+		logger.FromContext(ctx).Error("failed to check login attempts", "error", locked)
+		return Wrap[*entity.UserWithProfile](locked, "Failed to check login attempts")
+	}
+	if locked.Value() > 0 {
+		uc.metrics.LoginFailed("locked_out")
+		return loginLockedError(locked.Value())
+	}
+
+	result := uc.userRepo.FindByEmailWithProfile(ctx, dto.Email)
+	if result.IsError() && !errors.Is(result, ENTITY_NOT_FOUND) {
+		return Wrap[*entity.UserWithProfile](result, "Failed to find user")
+	}
+
+	// email yang tidak terdaftar tetap dibandingkan dengan hash dummy supaya waktu respons
+	// sama dengan password salah dan tidak bisa dipakai untuk menebak email yang terdaftar
+	hash := dummyPasswordHash()
+	if !result.IsError() {
+		hash = []byte(result.Value().PasswordHash)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(dto.Password)); err != nil || result.IsError() {
+		if result.IsError() {
+			uc.metrics.LoginFailed("email_not_found")
+		} else {
+			uc.metrics.LoginFailed("invalid_password")
+		}
+		return uc.loginFailed(ctx, dto.Email, ip)
+	}
+
+	if reset := uc.throttle.Succeeded(ctx, dto.Email); reset.IsError() {
+		logger.FromContext(ctx).Warn("failed to reset login attempts", "error", reset)
 	}
+
 	user := result.Value()
-	if user.UserType != entity.COLLECTOR {
-		return Err(result, "User selain collector tidak dapat login", true).WithCause(BAD_REQUEST_ERROR)
+	if !user.UserType.IsValid() || user.ProfileId == 0 {
+		uc.metrics.LoginFailed("profile_not_found")
+		return NewError[*entity.UserWithProfile]("User profile not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.USER_PROFILE_NOT_FOUND)
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(dto.Password)); err != nil {
-		return NewError[*entity.UserWithCollector]("Password salah", true).WithCause(CREDENTIALS_ERROR)
+	return uc.issueToken(ctx, user, uuid.NewString())
+}
+
+// loginFailed mencatat login gagal dan mengembalikan error yang sama untuk email tidak terdaftar
+// maupun password salah, atau error lockout jika batas gagal tercapai
+func (uc UserUsecase) loginFailed(ctx context.Context, email string, ip string) Result[*entity.UserWithProfile] {
+	lockout := uc.throttle.Failed(ctx, email, ip)
+	if lockout.IsError() {
+		logger.FromContext(ctx).Error("failed to record login attempt", "error", lockout)
+	} else if lockout.Value() > 0 {
+		return loginLockedError(lockout.Value())
 	}
 
-	userWithToken, err := auth.GenerateToken(user, uc.cfg)
-	if err != nil {
-		return NewError[*entity.UserWithCollector]("Gagal membuat token").WithCause(TOKEN_GENERATION_ERROR)
+	return NewError[*entity.UserWithProfile]("Invalid email or password", true).WithCause(CREDENTIALS_ERROR).WithMessage(i18n.INVALID_CREDENTIALS)
+}
+
+func loginLockedError(retryAfter time.Duration) Result[*entity.UserWithProfile] {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+
+	return NewError[*entity.UserWithProfile]("Too many failed login attempts", true).
+		WithCause(TOO_MANY_REQUESTS_ERROR).
+		WithMessage(i18n.LOGIN_LOCKED, seconds).
+		WithField(LOGIN_RETRY_AFTER_FIELD, seconds)
+}
+
+func (uc UserUsecase) UserUnlock(ctx context.Context, dto *dto.UserUnlockRequest) Result[bool] {
+	result := uc.throttle.Unlock(ctx, dto.Email, dto.IP)
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to unlock login", "error", result)
+		return Err(result, "Failed to unlock login")
 	}
 
-	return Ok(userWithToken)
+	logger.FromContext(ctx).Info("login unlocked", "email", dto.Email, "ip", dto.IP)
+
+	return Ok(true)
 }
 
 func (uc UserUsecase) UserUpdate(ctx context.Context, dto *dto.UserUpdateRequest) Result[*entity.User] {
@@ -92,11 +175,143 @@ func (uc UserUsecase) UserDelete(ctx context.Context, id int64) Result[bool] {
 func (uc UserUsecase) UserFind(ctx context.Context, id int64) Result[*entity.User] {
 	result := uc.userRepo.Find(ctx, id)
 	if result.IsError() {
-		return Err(result, "User tidak ditemukan", true)
+		return Err(result, "User not found", true).WithMessage(i18n.USER_NOT_FOUND)
 	}
 	return Ok(result.Value())
 }
 
+func (uc UserUsecase) UserRefreshToken(ctx context.Context, userId int64, tokenId string) Result[*entity.UserWithProfile] {
+	result := uc.tokenRepo.FindByTokenId(ctx, tokenId)
+	if result.IsError() {
+		if errors.Is(result, ENTITY_NOT_FOUND) {
+			return NewError[*entity.UserWithProfile]("Invalid refresh token", true).WithCause(CREDENTIALS_ERROR).WithMessage(i18n.INVALID_REFRESH_TOKEN)
+		}
+		logger.FromContext(ctx).Error("failed to check refresh token", "error", result)
+		return Wrap[*entity.UserWithProfile](result, "Failed to check refresh token")
+	}
+	token := result.Value()
+
+	if token.UserId != userId || token.RevokedAt != nil {
+		return NewError[*entity.UserWithProfile]("Invalid refresh token", true).WithCause(CREDENTIALS_ERROR).WithMessage(i18n.INVALID_REFRESH_TOKEN)
+	}
+
+	// token yang sudah pernah dipakai berarti kemungkinan dicuri, cabut seluruh keluarga token
+	if token.IsReused() {
+		return uc.revokeTokenFamily(ctx, token.FamilyId)
+	}
+
+	// token lama ditandai terpakai dan token baru disimpan dalam satu transaction. Jika penyimpanan
+	// token baru gagal, token lama tetap bisa dipakai lagi dan tidak dianggap sebagai reuse.
+	reused := false
+	issued := services.InTransaction(ctx, uc.uow, nil, func(ctx context.Context) Result[*entity.UserWithProfile] {
+		used := uc.tokenRepo.MarkUsed(ctx, tokenId)
+		if used.IsError() {
+			logger.FromContext(ctx).Error("failed to update refresh token", "error", used)
+			return Wrap[*entity.UserWithProfile](used, "Failed to update refresh token")
+		}
+		if !used.Value() {
+			// kalah balapan dengan request lain yang memakai token yang sama
+			reused = true
+			return NewError[*entity.UserWithProfile]("Refresh token has already been used", true).WithCause(CREDENTIALS_ERROR).WithMessage(i18n.REFRESH_TOKEN_REUSED)
+		}
+
+		userResult := uc.userRepo.FindWithProfile(ctx, userId)
+		if userResult.IsError() {
+			return Err(userResult, "User not found", true).WithMessage(i18n.USER_NOT_FOUND)
+		}
+
+		return uc.issueToken(ctx, userResult.Value(), token.FamilyId)
+	})
+
+	// keluarga token dicabut di luar transaction supaya pencabutannya tidak ikut di-rollback
+	if reused {
+		return uc.revokeTokenFamily(ctx, token.FamilyId)
+	}
+
+	return issued
+}
+
+func (uc UserUsecase) UserLogout(ctx context.Context, session *dto.UserSession) Result[bool] {
+	result := uc.revocations.Revoke(ctx, session.TokenId, session.UserId, session.ExpiresAt)
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to revoke token", "error", result)
+		return Wrap[bool](result, "Failed to revoke token")
+	}
+
+	// family diambil dari claim sid access token supaya client yang hanya memakai Bearer ikut
+	// mencabut refresh token-nya. Token lama tanpa sid memakai refresh token dari cookie jika ada.
+	familyId := session.FamilyId
+	if familyId == "" && session.RefreshTokenId != "" {
+		tokenResult := uc.tokenRepo.FindByTokenId(ctx, session.RefreshTokenId)
+		if !tokenResult.IsError() && tokenResult.Value().UserId == session.UserId {
+			familyId = tokenResult.Value().FamilyId
+		}
+	}
+	if familyId == "" {
+		return Ok(true)
+	}
+
+	revoked := uc.tokenRepo.RevokeFamily(ctx, familyId)
+	if revoked.IsError() {
+		logger.FromContext(ctx).Error("failed to revoke refresh token", "error", revoked)
+		return Wrap[bool](revoked, "Failed to revoke refresh token")
+	}
+
+	return Ok(true)
+}
+
+func (uc UserUsecase) UserLogoutAll(ctx context.Context, session *dto.UserSession) Result[bool] {
+	result := uc.revocations.Revoke(ctx, session.TokenId, session.UserId, session.ExpiresAt)
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to revoke token", "error", result)
+		return Wrap[bool](result, "Failed to revoke token")
+	}
+
+	// iat pada JWT hanya presisi detik, batas dibulatkan ke bawah supaya token hasil login ulang
+	// di detik yang sama tidak ikut dicabut (revoked_before > iat)
+	result = uc.revocations.RevokeUser(ctx, session.UserId, time.Now().Truncate(time.Second))
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to revoke all tokens", "error", result)
+		return Wrap[bool](result, "Failed to revoke all tokens")
+	}
+
+	revoked := uc.tokenRepo.RevokeAllByUser(ctx, session.UserId)
+	if revoked.IsError() {
+		logger.FromContext(ctx).Error("failed to revoke refresh token", "error", revoked)
+		return Wrap[bool](revoked, "Failed to revoke refresh token")
+	}
+
+	return Ok(true)
+}
+
+func (uc UserUsecase) issueToken(ctx context.Context, user *entity.UserWithProfile, familyId string) Result[*entity.UserWithProfile] {
+	userWithToken, claims, err := auth.GenerateToken(user, familyId, uc.keys, uc.cfg)
+	if err != nil {
+		return NewError[*entity.UserWithProfile]("Failed to generate token").WithCause(TOKEN_GENERATION_ERROR)
+	}
+
+	result := uc.tokenRepo.Create(ctx, &entity.RefreshToken{
+		UserId:    user.Id,
+		TokenId:   claims.ID,
+		FamilyId:  familyId,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+	if result.IsError() {
+		logger.FromContext(ctx).Error("failed to store refresh token", "error", result)
+		return NewError[*entity.UserWithProfile]("Failed to store refresh token").WithCause(TOKEN_GENERATION_ERROR)
+	}
+
+	return Ok(userWithToken)
+}
+
+func (uc UserUsecase) revokeTokenFamily(ctx context.Context, familyId string) Result[*entity.UserWithProfile] {
+	if result := uc.tokenRepo.RevokeFamily(ctx, familyId); result.IsError() {
+		logger.FromContext(ctx).Error("failed to revoke reused refresh token family", "error", result, "family_id", familyId)
+	}
+
+	return NewError[*entity.UserWithProfile]("Refresh token has already been used, please log in again", true).WithCause(CREDENTIALS_ERROR).WithMessage(i18n.REFRESH_TOKEN_REUSED)
+}
+
 // func generateToken(user *entity.User, cfg *config.Config) (*entity.User, error) {
 // 	at, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
 // 		Subject:   strconv.FormatInt(user.Id, 10),
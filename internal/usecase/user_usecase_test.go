@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/crazydw4rf/oil-bank-backend/internal/auth"
+	"github.com/crazydw4rf/oil-bank-backend/internal/dto"
+	"github.com/crazydw4rf/oil-bank-backend/internal/entity"
+	"github.com/crazydw4rf/oil-bank-backend/internal/pkg/i18n"
+	. "github.com/crazydw4rf/oil-bank-backend/internal/pkg/result"
+	"github.com/crazydw4rf/oil-bank-backend/internal/repository"
+	"github.com/crazydw4rf/oil-bank-backend/internal/services/config"
+	. "github.com/onsi/gomega"
+)
+
+// txParticipant adalah fake repository yang state-nya ikut di-rollback oleh fakeUnitOfWork
+type txParticipant interface {
+	snapshot() (restore func())
+}
+
+type fakeTxKey struct{}
+
+// fakeUnitOfWork meniru services.UnitOfWork: jika fn gagal, semua participant
+// dikembalikan ke state sebelum transaction dimulai
+type fakeUnitOfWork struct {
+	participants []txParticipant
+	commits      int
+	rollbacks    int
+}
+
+func (u *fakeUnitOfWork) Do(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	if ctx.Value(fakeTxKey{}) != nil {
+		return fn(ctx)
+	}
+
+	restores := make([]func(), 0, len(u.participants))
+	for _, p := range u.participants {
+		restores = append(restores, p.snapshot())
+	}
+
+	if err := fn(context.WithValue(ctx, fakeTxKey{}, true)); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		u.rollbacks++
+		return err
+	}
+
+	u.commits++
+	return nil
+}
+
+var errFakeDatabase = errors.New("connection reset by peer")
+
+type fakeRefreshTokenRepository struct {
+	repository.IRefreshTokenRepository
+	tokens     map[string]entity.RefreshToken
+	failCreate bool
+}
+
+func newFakeRefreshTokenRepository(tokens ...entity.RefreshToken) *fakeRefreshTokenRepository {
+	r := &fakeRefreshTokenRepository{tokens: map[string]entity.RefreshToken{}}
+	for _, token := range tokens {
+		r.tokens[token.TokenId] = token
+	}
+	return r
+}
+
+func (r *fakeRefreshTokenRepository) snapshot() func() {
+	saved := maps.Clone(r.tokens)
+	return func() { r.tokens = saved }
+}
+
+func (r *fakeRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) Result[*entity.RefreshToken] {
+	if r.failCreate {
+		return Wrap[*entity.RefreshToken](errFakeDatabase, "database error")
+	}
+	r.tokens[token.TokenId] = *token
+	return Ok(token)
+}
+
+func (r *fakeRefreshTokenRepository) FindByTokenId(ctx context.Context, tokenId string) Result[*entity.RefreshToken] {
+	token, ok := r.tokens[tokenId]
+	if !ok {
+		return NewError[*entity.RefreshToken]("token not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.TOKEN_NOT_FOUND)
+	}
+	return Ok(&token)
+}
+
+func (r *fakeRefreshTokenRepository) MarkUsed(ctx context.Context, tokenId string) Result[bool] {
+	token, ok := r.tokens[tokenId]
+	if !ok || !token.IsActive() {
+		return Ok(false)
+	}
+
+	now := time.Now()
+	token.UsedAt = &now
+	r.tokens[tokenId] = token
+	return Ok(true)
+}
+
+func (r *fakeRefreshTokenRepository) RevokeFamily(ctx context.Context, familyId string) Result[int64] {
+	var revoked int64
+	now := time.Now()
+	for id, token := range r.tokens {
+		if token.FamilyId == familyId && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			r.tokens[id] = token
+			revoked++
+		}
+	}
+	return Ok(revoked)
+}
+
+type fakeRevocationStore struct {
+	auth.RevocationStore
+	revoked []string
+}
+
+func (s *fakeRevocationStore) Revoke(ctx context.Context, tokenId string, userId int64, expiresAt time.Time) Result[bool] {
+	s.revoked = append(s.revoked, tokenId)
+	return Ok(true)
+}
+
+type fakeUserRepository struct {
+	repository.IUserRepository
+	users     map[int64]*entity.UserWithProfile
+	sellers   map[string]*entity.UserWithSeller
+	companies map[string]*entity.UserWithCompany
+}
+
+func (r *fakeUserRepository) FindWithProfile(ctx context.Context, id int64) Result[*entity.UserWithProfile] {
+	user, ok := r.users[id]
+	if !ok {
+		return NewError[*entity.UserWithProfile]("user not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.USER_NOT_FOUND)
+	}
+	return Ok(user)
+}
+
+func (r *fakeUserRepository) FindByEmailWithSeller(ctx context.Context, email string) Result[*entity.UserWithSeller] {
+	seller, ok := r.sellers[email]
+	if !ok {
+		return NewError[*entity.UserWithSeller]("user not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.USER_NOT_FOUND)
+	}
+	return Ok(seller)
+}
+
+func (r *fakeUserRepository) FindByEmailWithCompany(ctx context.Context, email string) Result[*entity.UserWithCompany] {
+	company, ok := r.companies[email]
+	if !ok {
+		return NewError[*entity.UserWithCompany]("user not found", true).WithCause(ENTITY_NOT_FOUND).WithMessage(i18n.USER_NOT_FOUND)
+	}
+	return Ok(company)
+}
+
+func setupRefreshUsecase(t *testing.T, tokens ...entity.RefreshToken) (UserUsecase, *fakeRefreshTokenRepository, *fakeUnitOfWork) {
+	cfg := &config.Config{
+		JWT_ACCESS_TOKEN_SECRET:       "access-secret-for-usecase-tests-0123456789",
+		JWT_REFRESH_TOKEN_SECRET:      "refresh-secret-for-usecase-tests-0123456789",
+		ACCESS_TOKEN_EXPIRATION_TIME:  config.DEFAULT_ACCESS_TOKEN_EXPIRATION_TIME,
+		REFRESH_TOKEN_EXPIRATION_TIME: config.DEFAULT_REFRESH_TOKEN_EXPIRATION_TIME,
+	}
+	keys, err := auth.NewAccessTokenKeys(cfg)
+	if err != nil {
+		t.Fatalf("failed to create keys: %v", err)
+	}
+
+	tokenRepo := newFakeRefreshTokenRepository(tokens...)
+	userRepo := &fakeUserRepository{users: map[int64]*entity.UserWithProfile{
+		7: {User: entity.User{Id: 7, UserType: entity.COLLECTOR}, ProfileId: 3},
+	}}
+	uow := &fakeUnitOfWork{participants: []txParticipant{tokenRepo}}
+
+	uc := UserUsecase{userRepo: userRepo, tokenRepo: tokenRepo, revocations: &fakeRevocationStore{}, uow: uow, keys: keys, cfg: cfg}
+
+	return uc, tokenRepo, uow
+}
+
+func activeRefreshToken(tokenId string) entity.RefreshToken {
+	return entity.RefreshToken{UserId: 7, TokenId: tokenId, FamilyId: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+func TestUserRefreshToken_Rotates(t *testing.T) {
+	g := NewWithT(t)
+	uc, tokenRepo, uow := setupRefreshUsecase(t, activeRefreshToken("old"))
+
+	result := uc.UserRefreshToken(context.Background(), 7, "old")
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(result.Value().RefreshToken).ToNot(BeEmpty())
+	g.Expect(tokenRepo.tokens["old"].UsedAt).ToNot(BeNil())
+	g.Expect(tokenRepo.tokens).To(HaveLen(2))
+	for _, token := range tokenRepo.tokens {
+		g.Expect(token.FamilyId).To(Equal("family-1"))
+		g.Expect(token.RevokedAt).To(BeNil())
+	}
+	g.Expect(uow.commits).To(Equal(1))
+}
+
+func TestUserRefreshToken_FailedCreateKeepsOldToken(t *testing.T) {
+	g := NewWithT(t)
+	uc, tokenRepo, uow := setupRefreshUsecase(t, activeRefreshToken("old"))
+	tokenRepo.failCreate = true
+
+	result := uc.UserRefreshToken(context.Background(), 7, "old")
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(errors.Is(result, TOKEN_GENERATION_ERROR)).To(BeTrue())
+	g.Expect(uow.rollbacks).To(Equal(1))
+	g.Expect(tokenRepo.tokens["old"].UsedAt).To(BeNil())
+
+	// retry dengan token yang sama tidak dianggap reuse
+	tokenRepo.failCreate = false
+	result = uc.UserRefreshToken(context.Background(), 7, "old")
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(tokenRepo.tokens["old"].RevokedAt).To(BeNil())
+}
+
+func TestUserRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	g := NewWithT(t)
+	used := activeRefreshToken("old")
+	usedAt := time.Now().Add(-time.Minute)
+	used.UsedAt = &usedAt
+	uc, tokenRepo, _ := setupRefreshUsecase(t, used, activeRefreshToken("current"))
+
+	result := uc.UserRefreshToken(context.Background(), 7, "old")
+
+	g.Expect(result.IsError()).To(BeTrue())
+	g.Expect(result.ExpectedError().MessageId()).To(Equal(i18n.REFRESH_TOKEN_REUSED))
+	g.Expect(tokenRepo.tokens["current"].RevokedAt).ToNot(BeNil())
+}
+
+func TestUserLogout_RevokesFamilyFromAccessTokenSession(t *testing.T) {
+	g := NewWithT(t)
+	uc, tokenRepo, _ := setupRefreshUsecase(t, activeRefreshToken("current"))
+
+	// client Bearer tidak mengirim cookie refresh token, family diambil dari claim sid
+	session := &dto.UserSession{UserId: 7, TokenId: "access", ExpiresAt: time.Now().Add(time.Minute), FamilyId: "family-1"}
+	result := uc.UserLogout(context.Background(), session)
+
+	g.Expect(result.IsError()).To(BeFalse())
+	g.Expect(uc.revocations.(*fakeRevocationStore).revoked).To(Equal([]string{"access"}))
+	g.Expect(tokenRepo.tokens["current"].RevokedAt).ToNot(BeNil())
+}